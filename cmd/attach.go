@@ -3,9 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/orchestrator"
 	"github.com/tarzzz/wildwest/pkg/session"
 	"github.com/spf13/cobra"
 )
@@ -91,10 +92,10 @@ func cleanupSessions(cmd *cobra.Command, args []string) error {
 	checked := 0
 	for _, sess := range sessions {
 		checked++
-		// Check if tmux session is running
-		isRunning := isTmuxSessionRunning(sess.ID)
+		// Check if worker process is running
+		isRunning := isSessionRunning(sess)
 
-		fmt.Printf("📋 Checking %s (%s): tmux=%v, status=%s\n", sess.PersonaName, sess.ID, isRunning, sess.Status)
+		fmt.Printf("📋 Checking %s (%s): running=%v, status=%s\n", sess.PersonaName, sess.ID, isRunning, sess.Status)
 
 		// Skip running sessions
 		if isRunning {
@@ -142,7 +143,7 @@ func listSessions(sessions []*session.Session, filter string) error {
 	fmt.Println("═══════════════════════════════════════════════════")
 	fmt.Println()
 
-	// Update session statuses based on tmux
+	// Update session statuses based on worker processes
 	sm, _ := session.NewSessionManager(workspaceDir)
 	for _, sess := range sessions {
 		updateSessionStatus(sm, sess)
@@ -182,8 +183,8 @@ func displaySessionType(byType map[session.SessionType][]*session.Session, sessi
 	fmt.Printf("╚══════════════════════════════════════════════════╝\n\n")
 
 	for _, sess := range sessions {
-		// Check actual tmux status
-		isRunning := isTmuxSessionRunning(sess.ID)
+		// Check actual worker process status
+		isRunning := isSessionRunning(sess)
 
 		statusIcon := "🔄"
 		statusText := sess.Status
@@ -192,6 +193,10 @@ func displaySessionType(byType map[session.SessionType][]*session.Session, sessi
 			statusIcon = "✅"
 		} else if sess.Status == "failed" {
 			statusIcon = "❌"
+		} else if sess.Status == orchestrator.StatusBudgetExceeded {
+			statusIcon = "🛑"
+		} else if (sess.Status == "restarting" || sess.Status == "resuming") && !isRunning {
+			statusIcon = "🔁"
 		} else if sess.Status == "stopped" || !isRunning {
 			statusIcon = "⏸️"
 			statusText = "stopped"
@@ -206,14 +211,21 @@ func displaySessionType(byType map[session.SessionType][]*session.Session, sessi
 		fmt.Printf("   Started: %s\n", sess.StartTime.Format("2006-01-02 15:04:05"))
 
 		if isRunning {
-			tmuxSessionName := fmt.Sprintf("claude-%s", sess.ID)
-			fmt.Printf("   Tmux: %s\n", tmuxSessionName)
+			b := backend.ForSession(sess.Backend, workspaceDir)
+			fmt.Printf("   Process: %s (%s)\n", backend.ProcessName(sess.ID), b.Name())
 		}
 
 		if sess.PID > 0 {
 			fmt.Printf("   PID: %d\n", sess.PID)
 		}
 
+		if sess.RestartCount > 0 {
+			fmt.Printf("   Restarts: %d (last: %s)\n", sess.RestartCount, sess.LastRestart.Format("2006-01-02 15:04:05"))
+		}
+		if sess.LastExitReason != "" {
+			fmt.Printf("   Last Exit: %s\n", sess.LastExitReason)
+		}
+
 		fmt.Println()
 	}
 }
@@ -230,20 +242,15 @@ func updateSessionStatus(sm *session.SessionManager, sess *session.Session) {
 		return
 	}
 
-	// Check if tmux session is running
-	tmuxSessionName := fmt.Sprintf("claude-%s", sess.ID)
-	checkCmd := exec.Command("tmux", "has-session", "-t", tmuxSessionName)
-	err := checkCmd.Run()
-
-	if err != nil {
-		// Tmux session not running
+	if !isSessionRunning(sess) {
+		// Worker process not running
 		if sess.Status == "active" || sess.Status == "running" {
 			// Was active but now stopped
 			sm.UpdateSessionStatus(sess.ID, "stopped")
 			sess.Status = "stopped"
 		}
 	} else {
-		// Tmux session is running
+		// Worker process is running
 		if sess.Status != "active" && sess.Status != "running" {
 			// Update to active
 			sm.UpdateSessionStatus(sess.ID, "active")
@@ -252,10 +259,9 @@ func updateSessionStatus(sm *session.SessionManager, sess *session.Session) {
 	}
 }
 
-func isTmuxSessionRunning(sessionID string) bool {
-	tmuxSessionName := fmt.Sprintf("claude-%s", sessionID)
-	checkCmd := exec.Command("tmux", "has-session", "-t", tmuxSessionName)
-	return checkCmd.Run() == nil
+func isSessionRunning(sess *session.Session) bool {
+	b := backend.ForSession(sess.Backend, workspaceDir)
+	return b.IsAlive(backend.ProcessName(sess.ID))
 }
 
 func attachTo(sm *session.SessionManager, sessionID string) error {
@@ -286,24 +292,37 @@ func attachTo(sm *session.SessionManager, sessionID string) error {
 		return fmt.Errorf("session %s not found", sessionID)
 	}
 
-	// Check if tmux session exists
-	tmuxSessionName := fmt.Sprintf("claude-%s", sessionID)
-	checkCmd := exec.Command("tmux", "has-session", "-t", tmuxSessionName)
-	if err := checkCmd.Run(); err != nil {
-		return fmt.Errorf("tmux session %s not running. Start the orchestrator first.", tmuxSessionName)
+	var sess *session.Session
+	sessions, err := sm.GetAllSessions()
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if s.ID == sessionID {
+			sess = s
+			break
+		}
+	}
+	if sess == nil {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+
+	// Check if worker process exists
+	b := backend.ForSession(sess.Backend, sm.GetWorkspacePath())
+	processName := backend.ProcessName(sessionID)
+	if !b.IsAlive(processName) {
+		return fmt.Errorf("worker %s not running. Start the orchestrator first.", processName)
 	}
 
 	fmt.Printf("🔗 Attaching to Claude session: %s\n", sessionID)
-	fmt.Printf("   Tmux session: %s\n", tmuxSessionName)
+	fmt.Printf("   Process: %s (%s)\n", processName, b.Name())
 	fmt.Printf("   Directory: %s\n\n", sessionDir)
-	fmt.Println("Press Ctrl+B then D to detach from this session")
+	if b.Name() == backend.NameTmux {
+		fmt.Println("Press Ctrl+B then D to detach from this session")
+	} else {
+		fmt.Println("Press Ctrl+C to stop following this session")
+	}
 	fmt.Println()
 
-	// Attach to tmux session
-	attachCmd := exec.Command("tmux", "attach-session", "-t", tmuxSessionName)
-	attachCmd.Stdin = os.Stdin
-	attachCmd.Stdout = os.Stdout
-	attachCmd.Stderr = os.Stderr
-
-	return attachCmd.Run()
+	return b.Attach(processName)
 }
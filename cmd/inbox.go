@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var inboxUnread bool
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox <session-id|name>",
+	Short: "Show the messages sent to a persona",
+	Long: `Show the messages in a persona's inbox, oldest first, including broadcasts
+sent since the persona started. Every message has an ID that can be passed to
+"wildwest ack", "wildwest thread" and "wildwest send --reply-to".
+
+Examples:
+  wildwest inbox software-engineer-1706012345678
+  wildwest inbox Turing --unread`,
+	Args: cobra.ExactArgs(1),
+	RunE: showInbox,
+}
+
+var ackCmd = &cobra.Command{
+	Use:   "ack <session-id|name> <message-id>...",
+	Short: "Mark messages in a persona's inbox as handled",
+	Long: `Mark messages in a persona's inbox as handled so "wildwest inbox --unread"
+no longer shows them.
+
+Examples:
+  wildwest ack software-engineer-1706012345678 msg-1706012399000-1a2b3c4d`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: ackMessages,
+}
+
+var threadCmd = &cobra.Command{
+	Use:   "thread <message-id>",
+	Short: "Show a message with its replies",
+	Args:  cobra.ExactArgs(1),
+	RunE:  showThread,
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd, ackCmd, threadCmd)
+	for _, c := range []*cobra.Command{inboxCmd, ackCmd, threadCmd} {
+		c.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+	}
+	inboxCmd.Flags().BoolVarP(&inboxUnread, "unread", "u", false, "only show messages that have not been acknowledged")
+}
+
+func showInbox(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	sess, err := resolveSession(sm, args[0])
+	if err != nil {
+		return err
+	}
+
+	messages, err := sm.ReadInbox(sess.ID, inboxUnread)
+	if err != nil {
+		return fmt.Errorf("failed to read inbox: %w", err)
+	}
+	unread, err := sm.ReadInbox(sess.ID, true)
+	if err != nil {
+		return fmt.Errorf("failed to read inbox: %w", err)
+	}
+	isUnread := make(map[string]bool)
+	for _, msg := range unread {
+		isUnread[msg.ID] = true
+	}
+
+	if len(messages) == 0 {
+		fmt.Printf("📭 No messages for %s (%s)\n", sess.PersonaName, sess.ID)
+		return nil
+	}
+
+	fmt.Printf("📬 Inbox of %s (%s): %d messages, %d unread\n", sess.PersonaName, sess.ID, len(messages), len(unread))
+	for _, msg := range messages {
+		displayMessage(msg, isUnread[msg.ID])
+	}
+	return nil
+}
+
+func ackMessages(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	sess, err := resolveSession(sm, args[0])
+	if err != nil {
+		return err
+	}
+
+	messages, err := sm.ReadInbox(sess.ID, false)
+	if err != nil {
+		return fmt.Errorf("failed to read inbox: %w", err)
+	}
+	inInbox := make(map[string]bool)
+	for _, msg := range messages {
+		inInbox[msg.ID] = true
+	}
+
+	for _, messageID := range args[1:] {
+		if !inInbox[messageID] {
+			return fmt.Errorf("message %s is not in the inbox of %s", messageID, sess.ID)
+		}
+		if err := sm.Ack(sess.ID, messageID); err != nil {
+			return fmt.Errorf("failed to acknowledge %s: %w", messageID, err)
+		}
+		fmt.Printf("✅ Acknowledged %s\n", messageID)
+	}
+	return nil
+}
+
+func showThread(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	thread, err := sm.GetThread(args[0])
+	if err != nil {
+		return err
+	}
+	for _, msg := range thread {
+		displayMessage(msg, false)
+	}
+	return nil
+}
+
+// displayMessage prints a message header followed by its indented content
+func displayMessage(msg session.Message, unread bool) {
+	to := msg.To
+	if to == "" {
+		to = "everyone"
+	}
+	marker := ""
+	if unread {
+		marker = " [unread]"
+	}
+
+	fmt.Printf("\n%s  %s → %s (%s, %s)%s\n", msg.ID, msg.From, to, msg.Type, msg.Timestamp.Format("2006-01-02 15:04:05"), marker)
+	if msg.ParentID != "" {
+		fmt.Printf("   in reply to: %s\n", msg.ParentID)
+	}
+	if msg.Subject != "" {
+		fmt.Printf("   Subject: %s\n", msg.Subject)
+	}
+	for _, line := range strings.Split(msg.Content, "\n") {
+		fmt.Printf("   %s\n", line)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/orchestrator"
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Integrate agent branches into the integration branch",
+	Long: `Integrate the branches of worktree-isolated agents (team start --isolate=worktree).
+
+When a session completes, the orchestrator merges its branch into the
+integration branch (merge.branch in ~/.wildwest.yaml, default
+wildwest/integration) and runs merge.verify. On a conflict or a failed
+verification the agent receives a merge report and its task is reopened.
+With merge.manual set, branches are only merged by "wildwest merge run".
+
+Examples:
+  wildwest merge status
+  wildwest merge run software-engineer-1706012345678
+  wildwest merge run`,
+}
+
+var mergeRunCmd = &cobra.Command{
+	Use:   "run [session-id|persona-type|name...]",
+	Short: "Merge session branches now (all unmerged active and completed sessions if none given)",
+	RunE:  runMerge,
+}
+
+var mergeStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the merge status of every session branch",
+	Args:  cobra.NoArgs,
+	RunE:  showMergeStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.AddCommand(mergeRunCmd, mergeStatusCmd)
+	mergeCmd.PersistentFlags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	var sessions []*session.Session
+	if len(args) == 0 {
+		all, err := mergeCandidates(sm)
+		if err != nil {
+			return err
+		}
+		for _, sess := range all {
+			if sess.Branch != "" && sess.MergeStatus != orchestrator.MergeStatusMerged {
+				sessions = append(sessions, sess)
+			}
+		}
+	} else {
+		for _, target := range args {
+			sess, err := resolveMergeTarget(sm, target)
+			if err != nil {
+				return err
+			}
+			if sess.Branch == "" {
+				return fmt.Errorf("%s (%s) has no branch; start the team with --isolate=worktree", sess.PersonaName, sess.ID)
+			}
+			sessions = append(sessions, sess)
+		}
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No unmerged branches.")
+		return nil
+	}
+
+	merger := orchestrator.NewMerger(sm, cfg.Merge)
+	failed := 0
+	for _, sess := range sessions {
+		fmt.Printf("🔀 %s (%s): %s → %s\n", sess.PersonaName, sess.ID, sess.Branch, cfg.Merge.IntegrationBranch())
+		result := merger.Merge(sess)
+		fmt.Printf("   %s %s\n", orchestrator.MergeStatusIcon(result.Status), result.Summary())
+		if result.Status == orchestrator.MergeStatusVerifyFailed && strings.TrimSpace(result.Output) != "" {
+			fmt.Printf("\n%s\n\n", result.Output)
+		}
+		if result.Status != orchestrator.MergeStatusMerged {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branches failed to merge", failed, len(sessions))
+	}
+	return nil
+}
+
+// mergeCandidates returns the active sessions and the completed ones, which
+// keep their branch after their directory is archived
+func mergeCandidates(sm *session.SessionManager) ([]*session.Session, error) {
+	sessions, err := sm.GetAllSessions()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, sess := range sessions {
+		seen[sess.ID] = true
+	}
+
+	history, err := sm.GetSessionHistory()
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range history {
+		if !seen[sess.ID] && sess.Status == "completed" {
+			sessions = append(sessions, sess)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions, nil
+}
+
+// resolveMergeTarget resolves a target like resolveSession, also accepting the
+// ID of a completed session
+func resolveMergeTarget(sm *session.SessionManager, target string) (*session.Session, error) {
+	sess, err := resolveSession(sm, target)
+	if err == nil {
+		return sess, nil
+	}
+
+	history, historyErr := sm.GetSessionHistory()
+	if historyErr != nil {
+		return nil, err
+	}
+	for _, completed := range history {
+		if completed.ID == target {
+			return completed, nil
+		}
+	}
+	return nil, err
+}
+
+func showMergeStatus(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	history, err := sm.GetSessionHistory()
+	if err != nil {
+		return err
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].StartTime.Before(history[j].StartTime)
+	})
+
+	shown := 0
+	for _, sess := range history {
+		if sess.Branch == "" {
+			continue
+		}
+		status := sess.MergeStatus
+		if status == "" {
+			status = "pending"
+		}
+		fmt.Printf("%s %s (%s)\n", orchestrator.MergeStatusIcon(sess.MergeStatus), sess.PersonaName, sess.ID)
+		fmt.Printf("   Branch: %s\n", sess.Branch)
+		fmt.Printf("   Merge:  %s\n", status)
+		if sess.MergeDetail != "" {
+			fmt.Printf("   Detail: %s\n", sess.MergeDetail)
+		}
+		shown++
+	}
+
+	if shown == 0 {
+		fmt.Println("No session branches. Start the team with --isolate=worktree to give agents their own branches.")
+	}
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/tarzzz/wildwest/pkg/backend"
 	"github.com/tarzzz/wildwest/pkg/orchestrator"
 	"github.com/spf13/cobra"
 )
 
 var (
-	useTUI bool
+	useTUI      bool
+	backendName string
 )
 
 var orchestrateCmd = &cobra.Command{
@@ -28,11 +30,17 @@ The orchestrator:
 - Archives finished work
 
 The orchestrator runs in its own tmux session in the background.
-You can attach to it at any time to monitor progress.
+You can attach to it at any time to monitor progress. When tmux is not
+installed, it runs in the foreground instead.
+
+Persona workers run under a process backend: "tmux" (one tmux session
+per persona) or "process" (plain subprocesses, for CI and containers).
+The default is tmux when available.
 
 Example:
   wildwest orchestrate --workspace .ww-db
   wildwest orchestrate --workspace .ww-db --tui  # Interactive TUI
+  wildwest orchestrate --workspace .ww-db --tui=false --backend process  # Headless
 
   # Then attach to monitor:
   tmux attach -t claude-orchestrator-*`,
@@ -43,15 +51,34 @@ func init() {
 	rootCmd.AddCommand(orchestrateCmd)
 	orchestrateCmd.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
 	orchestrateCmd.Flags().BoolVar(&useTUI, "tui", true, "run orchestrator with interactive TUI (default)")
+	orchestrateCmd.Flags().StringVar(&backendName, "backend", "", "worker process backend: tmux or process (default: auto-detect)")
+}
+
+// newOrchestrator creates an orchestrator honoring the --backend flag
+func newOrchestrator() (*orchestrator.Orchestrator, error) {
+	orch, err := orchestrator.NewOrchestrator(workspaceDir, cfgFile, verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+
+	if backendName != "" {
+		b, err := backend.New(backendName, backend.StateDir(workspaceDir))
+		if err != nil {
+			return nil, err
+		}
+		orch.SetBackend(b)
+	}
+
+	return orch, nil
 }
 
 func runOrchestrator(cmd *cobra.Command, args []string) error {
 	// Check if we're already inside a tmux session FIRST
 	if os.Getenv("TMUX") != "" {
 		// Already in tmux - run orchestrator with appropriate mode
-		orch, err := orchestrator.NewOrchestrator(workspaceDir, verbose)
+		orch, err := newOrchestrator()
 		if err != nil {
-			return fmt.Errorf("failed to create orchestrator: %w", err)
+			return err
 		}
 
 		// If TUI requested, run with TUI, otherwise run normal loop
@@ -64,14 +91,23 @@ func runOrchestrator(cmd *cobra.Command, args []string) error {
 	// Not in tmux - if TUI mode requested, run directly without tmux
 	if useTUI {
 		// Minimal output - just start TUI
-		orch, err := orchestrator.NewOrchestrator(workspaceDir, verbose)
+		orch, err := newOrchestrator()
 		if err != nil {
-			return fmt.Errorf("failed to create orchestrator: %w", err)
+			return err
 		}
 
 		return orch.RunTUI()
 	}
 
+	// No tmux available (CI, containers) - run the loop in the foreground
+	if _, err := exec.LookPath("tmux"); err != nil {
+		orch, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+		return orch.Run()
+	}
+
 	// Not in tmux and not TUI, spawn orchestrator in a new tmux session
 	return spawnOrchestratorInTmux()
 }
@@ -100,6 +136,18 @@ func spawnOrchestratorInTmux() error {
 	}
 	if useTUI {
 		orchestratorCmd += " --tui"
+	} else {
+		orchestratorCmd += " --tui=false"
+	}
+	if backendName != "" {
+		orchestratorCmd += " --backend " + backendName
+	}
+	if cfgFile != "" {
+		absConfig, err := filepath.Abs(cfgFile)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute config path: %w", err)
+		}
+		orchestratorCmd += " --config " + absConfig
 	}
 
 	// Create tmux session
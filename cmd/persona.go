@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/tarzzz/wildwest/pkg/persona"
 	"github.com/spf13/cobra"
@@ -52,7 +53,8 @@ func listPersonas(cmd *cobra.Command, args []string) error {
 	fmt.Println("==================")
 	fmt.Println()
 
-	for key, p := range personas.Personas {
+	for _, key := range personas.Types() {
+		p := personas.Personas[key]
 		fmt.Printf("%s (%s)\n", p.Name, key)
 		fmt.Printf("  Description: %s\n", p.Description)
 		fmt.Println()
@@ -78,13 +80,30 @@ func showPersona(cmd *cobra.Command, args []string) error {
 	fmt.Println("=" + string(make([]byte, len(p.Name)+9)))
 	fmt.Println()
 
-	fmt.Printf("Description: %s\n\n", p.Description)
+	fmt.Printf("Description: %s\n", p.Description)
+	if p.Model != "" {
+		fmt.Printf("Model: %s\n", p.Model)
+	}
+	if p.Extends != "" {
+		fmt.Printf("Extends: %s\n", p.Extends)
+	}
+	if len(p.Mixins) > 0 {
+		fmt.Printf("Mixins: %s\n", strings.Join(p.Mixins, ", "))
+	}
+	fmt.Println()
 
 	fmt.Println("Instructions:")
 	fmt.Println("-------------")
 	fmt.Println(p.Instructions)
 	fmt.Println()
 
+	if p.Scaffolding != "" {
+		fmt.Println("Scaffolding:")
+		fmt.Println("------------")
+		fmt.Println(p.Scaffolding)
+		fmt.Println()
+	}
+
 	if len(p.Capabilities) > 0 {
 		fmt.Println("Capabilities:")
 		fmt.Println("-------------")
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/orchestrator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportSince  string
+	reportUntil  string
+	reportFormat string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports across all team sessions",
+}
+
+var reportCostCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Report token usage and cost across all team sessions",
+	Long: `Report token usage and cost for every team session under the base workspace,
+including completed and archived personas. The report has a line per persona
+session plus rollups per persona type, per team and in total.
+
+--since and --until filter by when persona sessions started and accept a date
+(2006-01-02) or an RFC 3339 timestamp.
+
+Examples:
+  # Markdown report of everything
+  wildwest report cost
+
+  # Last month as CSV
+  wildwest report cost --since 2026-01-01 --until 2026-02-01 --format csv > january.csv
+
+  # JSON for further processing
+  wildwest report cost --format json | jq '.total'`,
+	Args: cobra.NoArgs,
+	RunE: reportCost,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportCostCmd)
+	reportCmd.PersistentFlags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "base workspace directory")
+	reportCostCmd.Flags().StringVar(&reportSince, "since", "", "only include sessions started at or after this date")
+	reportCostCmd.Flags().StringVar(&reportUntil, "until", "", "only include sessions started before this date")
+	reportCostCmd.Flags().StringVar(&reportFormat, "format", orchestrator.ReportFormatMarkdown, "output format: text, json, csv or markdown")
+}
+
+func reportCost(cmd *cobra.Command, args []string) error {
+	since, err := parseReportTime(reportSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseReportTime(reportUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	report, err := orchestrator.BuildHistoricalCostReport(workspaceDir, orchestrator.PricingTable(cfg), since, until)
+	if err != nil {
+		return fmt.Errorf("failed to build cost report: %w", err)
+	}
+
+	return report.Write(os.Stdout, reportFormat)
+}
+
+// parseReportTime parses a date or RFC 3339 timestamp; empty means no bound
+func parseReportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	requestsReason string
+	requestsBy     string
+)
+
+var requestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "Approve or deny spawn requests",
+	Long: `Review spawn requests waiting for approval.
+
+With approval.enabled in ~/.wildwest.yaml, *-request-* directories created by
+agents are queued instead of spawned immediately, unless an auto-approve rule
+accepts them. A denial reason is sent to the requesting agent.
+
+Examples:
+  wildwest requests list
+  wildwest requests approve software-engineer-request-backend
+  wildwest requests deny qa-request-extra --reason "QA is already covered"`,
+}
+
+var requestsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List spawn requests waiting for approval",
+	Args:  cobra.NoArgs,
+	RunE:  listSpawnRequests,
+}
+
+var requestsApproveCmd = &cobra.Command{
+	Use:   "approve <request-id>...",
+	Short: "Approve spawn requests",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return decideSpawnRequests(args, session.RequestApproved)
+	},
+}
+
+var requestsDenyCmd = &cobra.Command{
+	Use:   "deny <request-id>...",
+	Short: "Deny spawn requests",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return decideSpawnRequests(args, session.RequestDenied)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(requestsCmd)
+	requestsCmd.AddCommand(requestsListCmd, requestsApproveCmd, requestsDenyCmd)
+	requestsCmd.PersistentFlags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+	requestsCmd.PersistentFlags().StringVar(&requestsBy, "by", "user", "who made the decision")
+	requestsDenyCmd.Flags().StringVarP(&requestsReason, "reason", "r", "", "reason sent to the requesting agent")
+}
+
+func listSpawnRequests(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	requests, err := sm.LoadSpawnRequests()
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		fmt.Println("No spawn requests waiting for approval.")
+		return nil
+	}
+
+	for _, req := range requests {
+		icon := "📥"
+		switch req.Status {
+		case session.RequestApproved:
+			icon = "✅"
+		case session.RequestDenied:
+			icon = "🚫"
+		}
+		fmt.Printf("%s %s [%s]\n", icon, req.ID, req.Status)
+		fmt.Printf("   Type:      %s\n", req.PersonaType)
+		if req.Requester != "" {
+			fmt.Printf("   Requester: %s\n", req.Requester)
+		}
+		fmt.Printf("   Waiting:   %s\n", time.Since(req.RequestedAt).Round(time.Second))
+		if req.Summary != "" {
+			fmt.Printf("   Task:      %s\n", req.Summary)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func decideSpawnRequests(ids []string, status string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		reason := ""
+		if status == session.RequestDenied {
+			reason = requestsReason
+		}
+		req, err := sm.DecideSpawnRequest(id, status, reason, requestsBy)
+		if err != nil {
+			return err
+		}
+		if status == session.RequestApproved {
+			fmt.Printf("✅ Approved %s (%s)\n", req.ID, req.PersonaType)
+		} else {
+			fmt.Printf("🚫 Denied %s (%s)\n", req.ID, req.PersonaType)
+		}
+	}
+	fmt.Println("The orchestrator applies the decision on its next scan.")
+	return nil
+}
@@ -74,10 +74,10 @@ TEAM HIERARCHY:
 HOW IT WORKS:
 
   - Each persona runs in its own tmux session (claude-{session-id})
-  - Personas monitor instructions.md every 5 seconds for new tasks
-  - Communication happens via writing to each other's instructions.md
+  - Personas are run again as soon as their instructions.md grows
+  - Communication happens via "wildwest send", which appends to instructions.md
   - Task progress tracked in individual tasks.md files
-  - Completed sessions are automatically archived
+  - Completed sessions are archived once their completion gates pass
 
 EXAMPLES:
 
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/tarzzz/wildwest/pkg/claude"
 	"github.com/tarzzz/wildwest/pkg/config"
@@ -29,6 +30,7 @@ The prompt can be minimal and will be expanded if --expand is used.`,
 func init() {
 	rootCmd.AddCommand(runCmd)
 
+	runCmd.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory used in persona instructions")
 	runCmd.Flags().StringVarP(&envName, "env", "e", "", "environment name from config")
 	runCmd.Flags().StringVarP(&personaName, "persona", "p", "", "persona to use (engineering-manager, software-engineer, intern, solutions-architect)")
 	runCmd.Flags().StringVarP(&instructions, "instructions", "i", "", "custom instructions file path")
@@ -46,7 +48,7 @@ func runClaude(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load persona if specified
-	var personaInstructions string
+	var personaInstructions, model string
 	if personaName != "" {
 		personas, err := persona.LoadPersonas("")
 		if err != nil {
@@ -58,7 +60,15 @@ func runClaude(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		personaInstructions = p.FormatInstructions(prompt)
+		absWorkspace, err := filepath.Abs(workspaceDir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve workspace path: %w", err)
+		}
+		personaInstructions, err = p.FormatInstructions(prompt, absWorkspace)
+		if err != nil {
+			return err
+		}
+		model = p.Model
 		if verbose {
 			fmt.Printf("Using persona: %s\n", p.Name)
 		}
@@ -72,6 +82,7 @@ func runClaude(cmd *cobra.Command, args []string) error {
 		PersonaInstructions: personaInstructions,
 		ExpandPrompt:        shouldExpand,
 		CustomSpecs:         customSpecs,
+		Model:               model,
 		Verbose:             verbose,
 	}
 
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sendFile      string
+	sendBroadcast bool
+	sendFrom      string
+	sendType      string
+	sendReplyTo   string
+)
+
+var sendCmd = &cobra.Command{
+	Use:   "send [session-id|persona-type|name] [message]",
+	Short: "Send instructions to a persona",
+	Long: `Send instructions to one or more personas in the workspace.
+
+The target can be a session ID, a persona type (sends to every active session
+of that type) or an assigned persona name. The message is taken from the
+argument, from --file, or from stdin when the argument is "-" or omitted.
+With --reply-to the message is threaded under an earlier message and sent to
+its sender, so no target is given.
+
+Examples:
+  wildwest send engineering-manager "Prioritize the login flow"
+  wildwest send Turing --file feedback.md
+  git diff | wildwest send software-engineer-1706012345678 -
+  wildwest send --broadcast "Code freeze at 17:00"
+  wildwest send --reply-to msg-1706012399000-1a2b3c4d --from software-engineer-1706012345678 "Done, see PR #12"
+  wildwest send --type signoff --from qa-1706012345678 Turing "Reviewed and approved"`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: sendInstructions,
+}
+
+func init() {
+	rootCmd.AddCommand(sendCmd)
+	sendCmd.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+	sendCmd.Flags().StringVarP(&sendFile, "file", "f", "", "read the message from a file")
+	sendCmd.Flags().BoolVarP(&sendBroadcast, "broadcast", "b", false, "send to every active persona")
+	sendCmd.Flags().StringVar(&sendFrom, "from", "user", "sender recorded in the message")
+	sendCmd.Flags().StringVar(&sendType, "type", session.MessageTypeTask, "message type: "+strings.Join(session.MessageTypes, ", "))
+	sendCmd.Flags().StringVarP(&sendReplyTo, "reply-to", "r", "", "reply to a message ID, addressed to its sender (type response unless --type is set)")
+}
+
+func sendInstructions(cmd *cobra.Command, args []string) error {
+	var target string
+	var messageArgs []string
+	if sendBroadcast && sendReplyTo != "" {
+		return fmt.Errorf("use either --broadcast or --reply-to, not both")
+	}
+	if sendBroadcast || sendReplyTo != "" {
+		messageArgs = args
+	} else {
+		if len(args) == 0 {
+			return fmt.Errorf("target required (session ID, persona type or name), or use --broadcast")
+		}
+		target = args[0]
+		messageArgs = args[1:]
+	}
+
+	if !validMessageType(sendType) {
+		return fmt.Errorf("unknown message type %q (expected one of: %s)", sendType, strings.Join(session.MessageTypes, ", "))
+	}
+
+	message, err := readSendMessage(messageArgs)
+	if err != nil {
+		return err
+	}
+
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	// Only QA personas sign off through messages; people use "wildwest signoff"
+	if sendType == session.MessageTypeSignoff {
+		if from, err := sm.GetSession(sendFrom); err != nil || from.PersonaType != session.SessionTypeQA {
+			return fmt.Errorf("signoff messages must be sent --from a QA session; to sign off yourself use: wildwest signoff <session>")
+		}
+	}
+
+	if sendReplyTo != "" {
+		replyType := ""
+		if cmd.Flags().Changed("type") {
+			replyType = sendType
+		}
+		msg, err := sm.Reply(sendReplyTo, sendFrom, replyType, message)
+		if err != nil {
+			return fmt.Errorf("failed to send reply: %w", err)
+		}
+		fmt.Printf("↩️  Replied to %s (%s)\n", msg.To, msg.ID)
+		return nil
+	}
+
+	if sendBroadcast {
+		if err := sm.SendMessage(&session.Message{From: sendFrom, Type: sendType, Content: message}); err != nil {
+			return fmt.Errorf("failed to broadcast instructions: %w", err)
+		}
+		fmt.Println("📣 Broadcast sent to all active personas")
+		return nil
+	}
+
+	targets, err := sm.ResolveTargets(target)
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range targets {
+		if err := sm.SendMessage(&session.Message{From: sendFrom, To: sess.ID, Type: sendType, Content: message}); err != nil {
+			return fmt.Errorf("failed to send instructions to %s: %w", sess.ID, err)
+		}
+		fmt.Printf("📨 Sent to %s (%s)\n", sess.PersonaName, sess.ID)
+	}
+
+	return nil
+}
+
+// validMessageType reports whether t is a known message type
+func validMessageType(t string) bool {
+	for _, known := range session.MessageTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// readSendMessage returns the message from --file, the argument, or stdin
+func readSendMessage(args []string) (string, error) {
+	if sendFile != "" {
+		if len(args) > 0 {
+			return "", fmt.Errorf("use either a message argument or --file, not both")
+		}
+		data, err := os.ReadFile(sendFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read message file: %w", err)
+		}
+		return checkSendMessage(string(data))
+	}
+
+	if len(args) > 0 && args[0] != "-" {
+		return checkSendMessage(args[0])
+	}
+
+	// Read from stdin when explicitly requested or piped
+	if len(args) == 0 {
+		if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return "", fmt.Errorf("message required (argument, --file, or stdin)")
+		}
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read message from stdin: %w", err)
+	}
+	return checkSendMessage(string(data))
+}
+
+func checkSendMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", fmt.Errorf("message is empty")
+	}
+	return message, nil
+}
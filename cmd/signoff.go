@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var signoffBy string
+
+var signoffCmd = &cobra.Command{
+	Use:   "signoff <session-id|name> [note]",
+	Short: "Sign off a session's work as its human reviewer",
+	Long: `Sign off a session that is waiting for QA sign-off (gates qa_signoff in
+~/.wildwest.yaml), letting it complete without a QA persona's review.
+
+QA personas sign off with "wildwest send --type signoff" from their own
+session; signoff messages from anyone else are ignored.
+
+Examples:
+  wildwest signoff software-engineer-1706012345678
+  wildwest signoff Turing "Reviewed the login flow"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: signoffSession,
+}
+
+func init() {
+	rootCmd.AddCommand(signoffCmd)
+	signoffCmd.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+	signoffCmd.Flags().StringVar(&signoffBy, "by", "user", "who signed off")
+}
+
+func signoffSession(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	sess, err := resolveSession(sm, args[0])
+	if err != nil {
+		return err
+	}
+	if err := sm.RecordSignoff(sess.ID, signoffBy); err != nil {
+		return fmt.Errorf("failed to record signoff: %w", err)
+	}
+
+	note := "Your work has been reviewed and signed off."
+	if len(args) > 1 && strings.TrimSpace(args[1]) != "" {
+		note = args[1]
+	}
+	if err := sm.SendMessage(&session.Message{From: signoffBy, To: sess.ID, Type: session.MessageTypeNotification, Content: note}); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", sess.ID, err)
+	}
+
+	fmt.Printf("✍️  Signed off %s (%s)\n", sess.PersonaName, sess.ID)
+	return nil
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskAssign     string
+	taskDependsOn  string
+	taskPriority   string
+	taskDeadline   string
+	taskBy         string
+	taskAs         string
+	taskStatus     string
+	taskAssignee   string
+	taskUnassigned bool
+	taskAll        bool
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Manage the shared task board",
+	Long: `Manage the workspace-wide task board in shared/tasks.md.
+
+Board tasks have IDs B1, B2, ... Tasks assigned to a persona also appear in
+that persona's tasks.md, and status changes made there are copied back to the
+board. Unassigned tasks can be claimed by any idle persona.
+
+Examples:
+  wildwest task add "Add rate limiting to the API"
+  wildwest task add "Write API docs" --assign software-engineer --depends-on B1
+  wildwest task add "Fix the login outage" --priority urgent --deadline 2h
+  wildwest task claim B1 --as software-engineer-1706012345678
+  wildwest task done B1
+  wildwest task list --unassigned`,
+}
+
+var taskAddCmd = &cobra.Command{
+	Use:   "add <description>",
+	Short: "Add a task to the board",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  addBoardTask,
+}
+
+var taskClaimCmd = &cobra.Command{
+	Use:   "claim [task-id]",
+	Short: "Claim an unassigned task (the first open one if no ID is given)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  claimBoardTask,
+}
+
+var taskUpdateCmd = &cobra.Command{
+	Use:   "update <task-id>",
+	Short: "Change a task's status",
+	Args:  cobra.ExactArgs(1),
+	RunE:  updateBoardTask,
+}
+
+var taskDoneCmd = &cobra.Command{
+	Use:   "done <task-id>",
+	Short: "Mark a task completed",
+	Args:  cobra.ExactArgs(1),
+	RunE:  completeBoardTask,
+}
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks on the board",
+	Args:  cobra.NoArgs,
+	RunE:  listBoardTasks,
+}
+
+func init() {
+	rootCmd.AddCommand(taskCmd)
+	taskCmd.AddCommand(taskAddCmd, taskClaimCmd, taskUpdateCmd, taskDoneCmd, taskListCmd)
+	taskCmd.PersistentFlags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+
+	taskAddCmd.Flags().StringVar(&taskAssign, "assign", "", "assign to a session ID, persona type or name (default: unassigned)")
+	taskAddCmd.Flags().StringVar(&taskDependsOn, "depends-on", "", "comma separated tasks this task depends on")
+	taskAddCmd.Flags().StringVar(&taskBy, "by", "user", "who is adding the task")
+	taskAddCmd.Flags().StringVar(&taskPriority, "priority", "", "low, normal, high or urgent")
+	taskAddCmd.Flags().StringVar(&taskDeadline, "deadline", "", "when the task is due: \"2006-01-02 15:04\", a date, or a duration such as 4h")
+
+	taskClaimCmd.Flags().StringVar(&taskAs, "as", "", "session ID, persona type or name claiming the task (required)")
+	taskClaimCmd.MarkFlagRequired("as")
+
+	taskUpdateCmd.Flags().StringVar(&taskStatus, "status", "", "new status: not started, in progress or completed (required)")
+	taskUpdateCmd.MarkFlagRequired("status")
+
+	taskListCmd.Flags().StringVar(&taskAssignee, "assignee", "", "only tasks assigned to this session ID, persona type or name")
+	taskListCmd.Flags().BoolVar(&taskUnassigned, "unassigned", false, "only unassigned tasks")
+	taskListCmd.Flags().BoolVarP(&taskAll, "all", "a", false, "include completed tasks")
+}
+
+// resolveSession resolves a target to exactly one session
+func resolveSession(sm *session.SessionManager, target string) (*session.Session, error) {
+	sessions, err := sm.ResolveTargets(target)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) > 1 {
+		ids := make([]string, 0, len(sessions))
+		for _, sess := range sessions {
+			ids = append(ids, sess.ID)
+		}
+		return nil, fmt.Errorf("'%s' matches %d sessions (%s), use a session ID", target, len(sessions), strings.Join(ids, ", "))
+	}
+	return sessions[0], nil
+}
+
+func addBoardTask(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	assignTo := ""
+	if taskAssign != "" {
+		sess, err := resolveSession(sm, taskAssign)
+		if err != nil {
+			return err
+		}
+		assignTo = sess.ID
+	}
+
+	var dependsOn []string
+	for _, ref := range strings.Split(taskDependsOn, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			dependsOn = append(dependsOn, ref)
+		}
+	}
+
+	priority := ""
+	if taskPriority != "" {
+		p, err := session.ParsePriority(taskPriority)
+		if err != nil {
+			return err
+		}
+		priority = session.PriorityName(p)
+	}
+
+	var deadline time.Time
+	if taskDeadline != "" {
+		if deadline, err = session.ParseDeadline(taskDeadline, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	task, err := sm.AddBoardTask(strings.Join(args, " "), taskBy, assignTo, dependsOn, priority, deadline)
+	if err != nil {
+		return fmt.Errorf("failed to add task: %w", err)
+	}
+
+	if assignTo != "" {
+		fmt.Printf("📌 Added %s, assigned to %s\n", task.ID, assignTo)
+	} else {
+		fmt.Printf("📌 Added %s (unassigned)\n", task.ID)
+	}
+	return nil
+}
+
+func claimBoardTask(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	sess, err := resolveSession(sm, taskAs)
+	if err != nil {
+		return err
+	}
+
+	taskID := ""
+	if len(args) > 0 {
+		taskID = args[0]
+	}
+
+	task, err := sm.ClaimBoardTask(taskID, sess.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🙋 %s claimed %s: %s\n", sess.PersonaName, task.ID, task.Description)
+	return nil
+}
+
+func updateBoardTask(cmd *cobra.Command, args []string) error {
+	return setBoardTaskStatus(args[0], session.NormalizeTaskStatus(taskStatus))
+}
+
+func completeBoardTask(cmd *cobra.Command, args []string) error {
+	return setBoardTaskStatus(args[0], session.TaskStatusCompleted)
+}
+
+func setBoardTaskStatus(taskID string, status session.TaskStatus) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	task, err := sm.UpdateBoardTask(taskID, status)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✏️  %s is now %s\n", task.ID, task.Status)
+	return nil
+}
+
+func listBoardTasks(cmd *cobra.Command, args []string) error {
+	sm, err := session.NewSessionManager(workspaceDir)
+	if err != nil {
+		return err
+	}
+
+	// Pick up status changes personas made in their own tasks.md
+	if err := sm.SyncBoard(); err != nil {
+		return fmt.Errorf("failed to sync task board: %w", err)
+	}
+
+	board, err := sm.LoadBoard()
+	if err != nil {
+		return err
+	}
+
+	assignee := ""
+	if taskAssignee != "" {
+		sess, err := resolveSession(sm, taskAssignee)
+		if err != nil {
+			return err
+		}
+		assignee = sess.ID
+	}
+
+	shown := 0
+	for _, task := range board.Tasks {
+		if !taskAll && task.Status == session.TaskStatusCompleted {
+			continue
+		}
+		if taskUnassigned && task.AssignedTo != "" {
+			continue
+		}
+		if assignee != "" && task.AssignedTo != assignee {
+			continue
+		}
+
+		owner := task.AssignedTo
+		if owner == "" {
+			owner = "unassigned"
+		}
+		displayTask(task)
+		fmt.Printf("         owner: %s", owner)
+		if len(task.DependsOn) > 0 {
+			fmt.Printf(", depends on: %s", strings.Join(task.DependsOn, ", "))
+		}
+		if task.Priority != "" {
+			fmt.Printf(", priority: %s", task.Priority)
+		}
+		fmt.Println()
+		shown++
+	}
+
+	if shown == 0 {
+		fmt.Println("No matching tasks on the board")
+	}
+	return nil
+}
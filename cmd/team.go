@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/git"
 	"github.com/tarzzz/wildwest/pkg/orchestrator"
 	"github.com/tarzzz/wildwest/pkg/persona"
 	"github.com/tarzzz/wildwest/pkg/session"
@@ -22,6 +24,9 @@ var (
 	teamTask         string
 	autoRun          bool
 	useTUITeam       bool
+	teamBudgetUSD    float64
+	teamBudgetTokens int64
+	teamIsolate      string
 )
 
 var teamCmd = &cobra.Command{
@@ -61,11 +66,26 @@ func init() {
 	teamStartCmd.Flags().IntVar(&numInterns, "interns", 0, "number of intern sessions")
 	teamStartCmd.Flags().BoolVar(&autoRun, "run", false, "automatically start orchestration daemon after team creation")
 	teamStartCmd.Flags().BoolVar(&useTUITeam, "tui", false, "use interactive TUI for orchestrator (requires --run)")
+	teamStartCmd.Flags().Float64Var(&teamBudgetUSD, "budget", 0, "team budget in USD; agents are stopped when it is spent")
+	teamStartCmd.Flags().Int64Var(&teamBudgetTokens, "budget-tokens", 0, "team budget in tokens; agents are stopped when it is spent")
+	teamStartCmd.Flags().StringVar(&teamIsolate, "isolate", "", "isolate coding agents: \"worktree\" gives each engineer/intern its own git worktree and branch")
 }
 
 func startTeam(cmd *cobra.Command, args []string) error {
 	task := strings.Join(args, " ")
 
+	// Worktree isolation needs the workspace to live inside a git repository
+	switch teamIsolate {
+	case "", "none":
+		teamIsolate = ""
+	case session.IsolationWorktree:
+		if _, err := git.FindRepo(filepath.Dir(workspaceDir)); err != nil {
+			return fmt.Errorf("--isolate=worktree requires a git repository: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown isolation mode %q (expected \"worktree\" or \"none\")", teamIsolate)
+	}
+
 	// Generate session ID and create session directory
 	sessionID := session.GenerateSessionID()
 	sessionPath := filepath.Join(workspaceDir, sessionID)
@@ -108,12 +128,29 @@ func startTeam(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Session path: %s\n", sessionPath)
 	fmt.Printf("Workspace ID: %s\n\n", workspace.ID)
 
+	// Save the team budget for the orchestrator to enforce
+	budget := config.BudgetLimit{USD: teamBudgetUSD, Tokens: teamBudgetTokens}
+	if budget.IsSet() {
+		if err := orchestrator.SaveBudget(sessionPath, budget); err != nil {
+			return fmt.Errorf("failed to save budget: %w", err)
+		}
+		fmt.Printf("💰 Team budget: %s\n\n", formatBudget(budget))
+	}
+
+	// Record worktree isolation for the orchestrator
+	if teamIsolate != "" {
+		if err := sm.SetIsolation(teamIsolate); err != nil {
+			return fmt.Errorf("failed to save isolation mode: %w", err)
+		}
+		fmt.Printf("🌿 Isolation: each engineer and intern gets its own git worktree and branch\n\n")
+	}
+
 	// Create initial team structure (Manager only)
 	// All other resources will be requested dynamically by the manager
 
 	// Create Engineering Manager directory
 	fmt.Println("Creating Engineering Manager...")
-	managerSession, err := sm.CreateSession(session.SessionTypeEngineeringManager, "", workspace.ID)
+	managerSession, err := sm.CreateSession(session.SessionTypeEngineeringManager, "", workspace.ID, "")
 	if err != nil {
 		return err
 	}
@@ -152,7 +189,8 @@ func startTeam(cmd *cobra.Command, args []string) error {
 	fmt.Println("✅ Engineering Manager created successfully!")
 	fmt.Printf("📁 Workspace: %s\n\n", sm.GetWorkspacePath())
 	fmt.Println("ℹ️  The Engineering Manager will assess the task and request needed resources")
-	fmt.Println("   (Solutions Architect, Software Engineers, QA, Interns) dynamically.\n")
+	fmt.Println("   (Solutions Architect, Software Engineers, QA, Interns) dynamically.")
+	fmt.Println()
 
 	if autoRun {
 		// Spawn orchestrator in tmux session
@@ -174,6 +212,11 @@ func startTeam(cmd *cobra.Command, args []string) error {
 		// Build command: wildwest orchestrate --workspace <workspace> --no-tui
 		// (runs orchestrator loop, not TUI)
 		orchestrateCmd := fmt.Sprintf("wildwest orchestrate --workspace %s --tui=false", sessionPath)
+		if cfgFile != "" {
+			if absConfig, err := filepath.Abs(cfgFile); err == nil {
+				orchestrateCmd += " --config " + absConfig
+			}
+		}
 
 		// Start tmux session with orchestrator
 		tmuxCmd := exec.Command("tmux", "new-session", "-d", "-s", sessionName, orchestrateCmd)
@@ -193,7 +236,7 @@ func startTeam(cmd *cobra.Command, args []string) error {
 		if GitCommit != "unknown" && GitCommit != "" {
 			version = GitCommit[:7]
 		}
-		return orchestrator.RunStaticTUIWithWorkspace(sessionPath, version)
+		return orchestrator.RunStaticTUIWithWorkspace(sessionPath, cfgFile, version)
 	} else {
 		fmt.Println("⚠️  IMPORTANT: Start the orchestrator to spawn Claude instances:")
 		fmt.Printf("   wildwest orchestrate --workspace %s\n\n", sessionPath)
@@ -208,9 +251,21 @@ func startTeam(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatBudget describes a budget limit for display
+func formatBudget(limit config.BudgetLimit) string {
+	var parts []string
+	if limit.USD > 0 {
+		parts = append(parts, session.FormatCost(limit.USD))
+	}
+	if limit.Tokens > 0 {
+		parts = append(parts, session.FormatTokens(limit.Tokens)+" tokens")
+	}
+	return strings.Join(parts, " / ")
+}
+
 func startPersonaSession(sm *session.SessionManager, personas *persona.PersonaConfig, personaType session.SessionType, name string, workspaceID string, task string) (*session.Session, error) {
 	// Create session record
-	sess, err := sm.CreateSession(personaType, name, workspaceID)
+	sess, err := sm.CreateSession(personaType, name, workspaceID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -2,35 +2,56 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"time"
 
+	"github.com/tarzzz/wildwest/pkg/config"
 	"github.com/tarzzz/wildwest/pkg/orchestrator"
 	"github.com/tarzzz/wildwest/pkg/session"
 	"github.com/spf13/cobra"
 )
 
 var (
-	costWatch bool
+	costWatch    bool
+	costHistory  bool
+	costForecast bool
+	costWindow   time.Duration
+	costFormat   string
 )
 
 var teamCostCmd = &cobra.Command{
 	Use:   "cost",
-	Short: "Show token usage and estimated costs for the team",
-	Long: `Display current token usage and estimated costs across all active personas.
-Token usage is polled every minute from running Claude sessions.
+	Short: "Show token usage and costs for the team",
+	Long: `Display current token usage and costs across all active personas.
+Token usage is read from each persona's usage log, which workers append to
+after every Claude invocation.
 
 Examples:
   # Show current cost snapshot
   wildwest team cost
 
   # Watch costs update in real-time
-  wildwest team cost --watch`,
+  wildwest team cost --watch
+
+  # Show cost per hour and per persona type
+  wildwest team cost --history
+
+  # Project spend at the burn rate of the last 30 minutes
+  wildwest team cost --forecast --window 30m
+
+  # Export per-session, per-persona and team totals for a spreadsheet
+  wildwest team cost --format csv > cost.csv`,
 	RunE: teamCost,
 }
 
 func init() {
 	teamCmd.AddCommand(teamCostCmd)
 	teamCostCmd.Flags().BoolVarP(&costWatch, "watch", "w", false, "continuously watch and update costs every minute")
+	teamCostCmd.Flags().BoolVar(&costHistory, "history", false, "show cost per hour and per persona type")
+	teamCostCmd.Flags().BoolVar(&costForecast, "forecast", false, "show the burn rate and projected spend")
+	teamCostCmd.Flags().StringVar(&costFormat, "format", orchestrator.ReportFormatText, "output format: text, json, csv or markdown")
+	teamCostCmd.Flags().DurationVar(&costWindow, "window", orchestrator.DefaultBurnRateWindow, "time window used to measure the burn rate")
 }
 
 func teamCost(cmd *cobra.Command, args []string) error {
@@ -39,12 +60,46 @@ func teamCost(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create session manager: %w", err)
 	}
 
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	sm.SetPricing(orchestrator.PricingTable(cfg))
+
+	// Machine-readable reports include completed and archived sessions
+	if costFormat != orchestrator.ReportFormatText {
+		report, err := orchestrator.BuildCostReport(sm)
+		if err != nil {
+			return fmt.Errorf("failed to build cost report: %w", err)
+		}
+		return report.Write(os.Stdout, costFormat)
+	}
+
 	monitor := orchestrator.NewCostMonitor(sm)
 
+	if costHistory || costForecast {
+		if costHistory {
+			summary, err := monitor.GetCostHistorySummary()
+			if err != nil {
+				return fmt.Errorf("failed to load cost history: %w", err)
+			}
+			fmt.Println(summary)
+		}
+		if costForecast {
+			summary, err := monitor.GetForecastSummary(costWindow)
+			if err != nil {
+				return fmt.Errorf("failed to load cost history: %w", err)
+			}
+			fmt.Println(summary)
+		}
+		return nil
+	}
+
 	if costWatch {
 		// Watch mode - update every minute
 		fmt.Println("Starting cost monitor in watch mode...")
-		fmt.Println("Press Ctrl+C to exit\n")
+		fmt.Println("Press Ctrl+C to exit")
+		fmt.Println()
 
 		// Show initial summary
 		summary, err := monitor.GetCurrentCostSummary()
@@ -85,10 +140,18 @@ func teamCost(cmd *cobra.Command, args []string) error {
 		// Show pricing reference
 		fmt.Println("\n💡 Pricing Reference (per 1M tokens)")
 		fmt.Println("=====================================")
-		fmt.Println("Claude Sonnet: $3.00 input / $15.00 output")
-		fmt.Println("Claude Opus:   $15.00 input / $75.00 output")
-		fmt.Println("Claude Haiku:  $0.25 input / $1.25 output")
-		fmt.Println("\nNote: Token usage is updated every minute by the orchestrator")
+		table := sm.Pricing().Models()
+		models := make([]string, 0, len(table))
+		for model := range table {
+			models = append(models, model)
+		}
+		sort.Strings(models)
+		for _, model := range models {
+			p := table[model]
+			fmt.Printf("%-14s $%.2f input / $%.2f output / $%.2f cache read / $%.2f cache write\n",
+				model+":", p.InputPer1M, p.OutputPer1M, p.CacheReadPer1M, p.CacheWritePer1M)
+		}
+		fmt.Println("\nNote: Costs reported by Claude are used when available, unless the model's pricing is configured")
 	}
 
 	return nil
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/tarzzz/wildwest/pkg/session"
 	"github.com/spf13/cobra"
@@ -16,13 +18,25 @@ This is a read-only view that shows:
 - What each persona is working on
 - Task completion status
 - Instructions assigned to each persona
-- Overall project progress`,
+- Overall project progress
+
+With --graph, shows the task dependency graph across personas instead: which
+tasks are blocked and by what, and the critical path of unfinished tasks.
+
+With --tree, shows who spawned whom, including completed sessions.`,
 	RunE: trackTeam,
 }
 
+var (
+	trackGraph bool
+	trackTree  bool
+)
+
 func init() {
 	rootCmd.AddCommand(trackCmd)
 	trackCmd.Flags().StringVarP(&workspaceDir, "workspace", "w", ".ww-db", "workspace directory")
+	trackCmd.Flags().BoolVar(&trackGraph, "graph", false, "show the task dependency graph and critical path")
+	trackCmd.Flags().BoolVar(&trackTree, "tree", false, "show the spawn tree: which session requested which")
 }
 
 func trackTeam(cmd *cobra.Command, args []string) error {
@@ -31,6 +45,13 @@ func trackTeam(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if trackGraph {
+		return displayTaskGraph(sm)
+	}
+	if trackTree {
+		return displaySpawnTree(sm)
+	}
+
 	sessions, err := sm.GetAllSessions()
 	if err != nil {
 		return err
@@ -58,6 +79,22 @@ func trackTeam(cmd *cobra.Command, args []string) error {
 	displayPersonaGroup(sm, session.SessionTypeSoftwareEngineer, "SOFTWARE ENGINEERS", personaMap)
 	displayPersonaGroup(sm, session.SessionTypeIntern, "INTERNS", personaMap)
 
+	// QA, DevOps and custom personas follow by type
+	var others []string
+	for personaType := range personaMap {
+		switch personaType {
+		case session.SessionTypeEngineeringManager, session.SessionTypeSolutionsArchitect,
+			session.SessionTypeSoftwareEngineer, session.SessionTypeIntern:
+			continue
+		}
+		others = append(others, string(personaType))
+	}
+	sort.Strings(others)
+	for _, personaType := range others {
+		title := strings.ToUpper(strings.ReplaceAll(personaType, "-", " "))
+		displayPersonaGroup(sm, session.SessionType(personaType), title, personaMap)
+	}
+
 	// Overall summary
 	fmt.Println("\n═══════════════════════════════════════════════════")
 	fmt.Println("                OVERALL SUMMARY")
@@ -66,14 +103,17 @@ func trackTeam(cmd *cobra.Command, args []string) error {
 	totalTasks := 0
 	completedTasks := 0
 	inProgressTasks := 0
+	overdueTasks := 0
 
 	for _, sess := range sessions {
-		tasks, _ := sm.ReadTasks(sess.ID)
-		completed := strings.Count(tasks, "completed")
-		inProgress := strings.Count(tasks, "in progress")
-		totalTasks += strings.Count(tasks, "## Task:")
-		completedTasks += completed
-		inProgressTasks += inProgress
+		tasks, err := sm.LoadTasks(sess.ID)
+		if err != nil {
+			continue
+		}
+		totalTasks += len(tasks.Tasks)
+		completedTasks += tasks.Count(session.TaskStatusCompleted)
+		inProgressTasks += tasks.Count(session.TaskStatusInProgress)
+		overdueTasks += tasks.CountOverdue(time.Now())
 	}
 
 	fmt.Printf("\nTotal Team Members: %d\n", len(sessions))
@@ -81,6 +121,14 @@ func trackTeam(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Completed: %d\n", completedTasks)
 	fmt.Printf("In Progress: %d\n", inProgressTasks)
 	fmt.Printf("Not Started: %d\n", totalTasks-completedTasks-inProgressTasks)
+	if graph, err := sm.BuildTaskGraph(); err == nil {
+		if blocked := graph.Blocked(); len(blocked) > 0 {
+			fmt.Printf("Blocked: %d (see wildwest track --graph)\n", len(blocked))
+		}
+	}
+	if overdueTasks > 0 {
+		fmt.Printf("⏰ Overdue: %d\n", overdueTasks)
+	}
 
 	if totalTasks > 0 {
 		completion := float64(completedTasks) / float64(totalTasks) * 100
@@ -105,10 +153,29 @@ func displayPersonaGroup(sm *session.SessionManager, personaType session.Session
 		fmt.Printf("📋 %s (%s)\n", sess.PersonaName, sess.ID)
 		fmt.Printf("   Status: %s\n", sess.Status)
 		fmt.Printf("   Started: %s\n", sess.StartTime.Format("2006-01-02 15:04:05"))
+		if sess.ParentSessionID != "" {
+			fmt.Printf("   Requested by: %s\n", sess.ParentSessionID)
+		}
+		if sess.Branch != "" {
+			fmt.Printf("   Branch: %s (%s)\n", sess.Branch, sess.WorktreePath)
+		}
+		if sess.Priority != session.PriorityNormal {
+			fmt.Printf("   Priority: %s\n", session.PriorityName(sess.Priority))
+		}
+		if !sess.Deadline.IsZero() {
+			overdue := ""
+			if sess.Overdue(time.Now()) {
+				overdue = " ⏰ OVERDUE"
+			}
+			fmt.Printf("   Deadline: %s (%s)%s\n", sess.Deadline.Format(session.TaskTimeFormat), session.FormatDue(sess.Deadline, time.Now()), overdue)
+		}
+		if len(sess.Labels) > 0 {
+			fmt.Printf("   Labels: %s\n", strings.Join(sess.Labels, ", "))
+		}
 
 		// Read and display tasks
-		tasks, err := sm.ReadTasks(sess.ID)
-		if err == nil && tasks != "" {
+		tasks, err := sm.LoadTasks(sess.ID)
+		if err == nil && len(tasks.Tasks) > 0 {
 			fmt.Println("\n   Current Tasks:")
 			displayTaskSummary(tasks)
 		}
@@ -135,50 +202,160 @@ func displayPersonaGroup(sm *session.SessionManager, personaType session.Session
 	}
 }
 
-func displayTaskSummary(tasks string) {
-	lines := strings.Split(tasks, "\n")
-	currentTask := ""
-	currentStatus := ""
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "## Task:") {
-			if currentTask != "" {
-				displayTask(currentTask, currentStatus)
-			}
-			currentTask = strings.TrimPrefix(line, "## Task:")
-			currentTask = strings.TrimSpace(currentTask)
-			currentStatus = ""
-		} else if strings.HasPrefix(line, "- **Status**:") {
-			currentStatus = strings.TrimPrefix(line, "- **Status**:")
-			currentStatus = strings.TrimSpace(currentStatus)
-		}
-	}
-
-	if currentTask != "" {
-		displayTask(currentTask, currentStatus)
+func displayTaskSummary(tasks *session.TaskList) {
+	for _, task := range tasks.Tasks {
+		displayTask(task)
 	}
 }
 
-func displayTask(task string, status string) {
+func displayTask(task session.Task) {
 	var icon string
-	switch status {
-	case "completed":
+	switch task.Status {
+	case session.TaskStatusCompleted:
 		icon = "✅"
-	case "in progress":
+	case session.TaskStatusInProgress:
 		icon = "🔄"
-	case "not started":
+	case session.TaskStatusNotStarted:
 		icon = "⏸️"
 	default:
 		icon = "❓"
 	}
 
-	// Truncate long task descriptions
-	if len(task) > 60 {
-		task = task[:57] + "..."
+	fmt.Printf("      %s %s: %s [%s]%s\n", icon, task.ID, truncateTask(task.Description), task.Status, taskSchedule(task))
+}
+
+// taskSchedule describes a task's priority and deadline, flagging overdue tasks
+func taskSchedule(task session.Task) string {
+	var parts []string
+	if task.Priority != "" && task.PriorityValue() != session.PriorityNormal {
+		parts = append(parts, task.Priority)
+	}
+	now := time.Now()
+	switch {
+	case task.Overdue(now):
+		parts = append(parts, fmt.Sprintf("⏰ OVERDUE, due %s", session.FormatDue(task.Deadline, now)))
+	case !task.Deadline.IsZero() && task.Status != session.TaskStatusCompleted:
+		parts = append(parts, fmt.Sprintf("due %s", session.FormatDue(task.Deadline, now)))
+	}
+	if len(parts) == 0 {
+		return ""
 	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
 
-	fmt.Printf("      %s %s [%s]\n", icon, task, status)
+// displayTaskGraph prints every task with its dependencies, then the critical path
+func displayTaskGraph(sm *session.SessionManager) error {
+	graph, err := sm.BuildTaskGraph()
+	if err != nil {
+		return err
+	}
+
+	if len(graph.Keys) == 0 {
+		fmt.Println("No tasks found in workspace")
+		return nil
+	}
+
+	fmt.Println("═══════════════════════════════════════════════════")
+	fmt.Println("              TASK DEPENDENCY GRAPH")
+	fmt.Println("═══════════════════════════════════════════════════")
+
+	currentSession := ""
+	for _, key := range graph.Keys {
+		node := graph.Nodes[key]
+		if node.SessionID != currentSession {
+			currentSession = node.SessionID
+			fmt.Printf("\n📋 %s (%s)\n", node.PersonaName, node.SessionID)
+		}
+
+		blockers := graph.Blockers(key)
+		if len(blockers) > 0 && !node.Done() {
+			fmt.Printf("      ⛔ %s: %s [blocked]\n", node.Task.ID, truncateTask(node.Task.Description))
+		} else {
+			displayTask(node.Task)
+		}
+
+		for _, dep := range node.DependsOn {
+			depNode := graph.Nodes[dep]
+			marker := "✅"
+			if !depNode.Done() {
+				marker = "⏳"
+			}
+			fmt.Printf("         └─ depends on %s %s: %s (%s)\n", marker, dep, truncateTask(depNode.Task.Description), depNode.PersonaName)
+		}
+		for _, ref := range node.Unresolved {
+			fmt.Printf("         └─ depends on ❓ %s (unknown task)\n", ref)
+		}
+	}
+
+	fmt.Println("\n═══════════════════════════════════════════════════")
+	fmt.Println("                 CRITICAL PATH")
+	fmt.Println("═══════════════════════════════════════════════════")
+
+	critical := graph.CriticalPath()
+	if len(critical) == 0 {
+		fmt.Println("\nAll tasks are completed")
+	} else {
+		fmt.Printf("\n%d unfinished task(s) in the longest dependency chain:\n\n", len(critical))
+		for i, key := range critical {
+			node := graph.Nodes[key]
+			fmt.Printf("   %d. %s: %s (%s) [%s]\n", i+1, key, truncateTask(node.Task.Description), node.PersonaName, node.Task.Status)
+		}
+	}
+
+	if cycles := graph.Cycles(); len(cycles) > 0 {
+		fmt.Println("\n⚠️  Dependency cycles (these tasks can never start):")
+		for _, key := range cycles {
+			fmt.Printf("   • %s\n", key)
+		}
+	}
+
+	return nil
+}
+
+// displaySpawnTree prints every session nested under the session that requested it
+func displaySpawnTree(sm *session.SessionManager) error {
+	sessions, err := sm.GetSessionHistory()
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No team sessions found in workspace")
+		return nil
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	fmt.Println("═══════════════════════════════════════════════════")
+	fmt.Println("                 SPAWN TREE")
+	fmt.Println("═══════════════════════════════════════════════════")
+	fmt.Println()
+
+	roots := session.BuildLineage(sessions)
+	for i, root := range roots {
+		displayLineageNode(root, "", i == len(roots)-1)
+	}
+	return nil
+}
+
+func displayLineageNode(node *session.LineageNode, indent string, last bool) {
+	branch, next := "├─ ", "│  "
+	if last {
+		branch, next = "└─ ", "   "
+	}
+	sess := node.Session
+	fmt.Printf("%s%s%s (%s) [%s]\n", indent, branch, sess.PersonaName, sess.ID, sess.Status)
+	for i, child := range node.Children {
+		displayLineageNode(child, indent+next, i == len(node.Children)-1)
+	}
+}
+
+// truncateTask shortens a task description for one-line display
+func truncateTask(description string) string {
+	if runes := []rune(description); len(runes) > 60 {
+		return string(runes[:57]) + "..."
+	}
+	return description
 }
 
 func displayLatestInstructions(instructions string) {
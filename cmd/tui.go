@@ -34,7 +34,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 
 	// If specific workspace provided, use it directly
 	if tuiWorkspace != "" {
-		return orchestrator.RunStaticTUIWithWorkspace(tuiWorkspace, version)
+		return orchestrator.RunStaticTUIWithWorkspace(tuiWorkspace, cfgFile, version)
 	}
 
 	// Otherwise, list sessions and let user select
@@ -51,7 +51,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	// If only one session, load it directly
 	if len(sessions) == 1 {
 		fmt.Printf("Loading session: %s\n", sessions[0].Description)
-		return orchestrator.RunStaticTUIWithWorkspace(sessions[0].WorkspacePath, version)
+		return orchestrator.RunStaticTUIWithWorkspace(sessions[0].WorkspacePath, cfgFile, version)
 	}
 
 	// Multiple sessions - show selector
@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Backend names
+const (
+	NameTmux    = "tmux"
+	NameProcess = "process"
+)
+
+// Backend runs persona worker processes.
+//
+// Each worker is identified by a process name (see ProcessName). Backends
+// must be usable from a different OS process than the one that spawned the
+// worker, so any state they need is kept outside of memory (tmux server,
+// pid files, ...).
+type Backend interface {
+	// Name returns the backend name recorded in session.json
+	Name() string
+	// Spawn starts `bash script` in dir under the given process name
+	Spawn(name, script, dir string) error
+	// IsAlive reports whether the named process is still running
+	IsAlive(name string) bool
+	// CaptureOutput returns the last n lines of output
+	CaptureOutput(name string, lines int) (string, error)
+	// Kill terminates the named process
+	Kill(name string) error
+	// Attach connects the current terminal to the named process
+	Attach(name string) error
+	// AttachCommand returns a shell command a user can run to attach
+	AttachCommand(name string) string
+}
+
+// processPrefix starts the process name of every persona session
+const processPrefix = "claude-"
+
+// ProcessName returns the process name used for a persona session
+func ProcessName(sessionID string) string {
+	return processPrefix + sessionID
+}
+
+// SessionID returns the session ID a process name was made from
+func SessionID(processName string) string {
+	return strings.TrimPrefix(processName, processPrefix)
+}
+
+// New creates a backend by name. An empty name picks tmux when it is
+// installed and falls back to plain subprocesses otherwise.
+// stateDir is where the process backend keeps its pid and log files.
+func New(name, stateDir string) (Backend, error) {
+	if name == "" {
+		name = Detect()
+	}
+
+	switch name {
+	case NameTmux:
+		return NewTmuxBackend(), nil
+	case NameProcess:
+		return NewProcessBackend(stateDir), nil
+	default:
+		return nil, fmt.Errorf("unknown backend '%s' (expected %s or %s)", name, NameTmux, NameProcess)
+	}
+}
+
+// Detect returns the default backend name for this machine
+func Detect() string {
+	if _, err := exec.LookPath("tmux"); err == nil {
+		return NameTmux
+	}
+	return NameProcess
+}
+
+// StateDir returns the directory where workspace-scoped backend state lives
+func StateDir(workspacePath string) string {
+	return filepath.Join(workspacePath, "orchestrator", "processes")
+}
+
+// ForSession returns the backend that spawned a session. Sessions created
+// before backends were recorded in session.json always ran under tmux.
+func ForSession(backendName, workspacePath string) Backend {
+	if backendName == "" {
+		backendName = NameTmux
+	}
+	b, err := New(backendName, StateDir(workspacePath))
+	if err != nil {
+		return NewTmuxBackend()
+	}
+	return b
+}
@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ProcessBackend runs each worker as a plain detached subprocess.
+// It needs no terminal multiplexer, which makes it suitable for CI boxes
+// and containers. Output goes to <stateDir>/<name>.log and the process ID
+// is kept in <stateDir>/<name>.pid so other commands can find it.
+type ProcessBackend struct {
+	stateDir string
+}
+
+// NewProcessBackend creates a subprocess backend keeping state in stateDir
+func NewProcessBackend(stateDir string) *ProcessBackend {
+	return &ProcessBackend{stateDir: stateDir}
+}
+
+// Name returns the backend name
+func (p *ProcessBackend) Name() string {
+	return NameProcess
+}
+
+func (p *ProcessBackend) pidPath(name string) string {
+	return filepath.Join(p.stateDir, name+".pid")
+}
+
+// LogPath returns the file the named process writes its output to
+func (p *ProcessBackend) LogPath(name string) string {
+	return filepath.Join(p.stateDir, name+".log")
+}
+
+// Spawn starts `bash script` in its own session with output sent to the log file
+func (p *ProcessBackend) Spawn(name, script, dir string) error {
+	if p.IsAlive(name) {
+		return fmt.Errorf("process %s is already running", name)
+	}
+
+	if err := os.MkdirAll(p.stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create process state directory: %w", err)
+	}
+
+	logFile, err := os.OpenFile(p.LogPath(name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command("bash", script)
+	cmd.Dir = dir
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// Detach from our terminal so the worker outlives the orchestrator
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start process: %w", err)
+	}
+
+	pid := strconv.Itoa(cmd.Process.Pid)
+	if err := os.WriteFile(p.pidPath(name), []byte(pid), 0644); err != nil {
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+
+	// Reap the child so it does not linger as a zombie while we are running
+	go cmd.Wait()
+
+	return nil
+}
+
+// readPID returns the recorded process ID, or 0 if there is none
+func (p *ProcessBackend) readPID(name string) int {
+	data, err := os.ReadFile(p.pidPath(name))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// IsAlive checks whether the recorded process still exists
+func (p *ProcessBackend) IsAlive(name string) bool {
+	pid := p.readPID(name)
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// CaptureOutput returns the last n lines of the process log
+func (p *ProcessBackend) CaptureOutput(name string, lines int) (string, error) {
+	data, err := os.ReadFile(p.LogPath(name))
+	if err != nil {
+		return "", err
+	}
+
+	all := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n"), nil
+}
+
+// Kill terminates the whole process group of the worker
+func (p *ProcessBackend) Kill(name string) error {
+	pid := p.readPID(name)
+	if pid <= 0 {
+		return fmt.Errorf("process %s not found", name)
+	}
+
+	// The worker is a session leader, so its pid is also its process group id
+	err := syscall.Kill(-pid, syscall.SIGTERM)
+	os.Remove(p.pidPath(name))
+	return err
+}
+
+// Attach follows the process log until interrupted with Ctrl+C
+func (p *ProcessBackend) Attach(name string) error {
+	if !p.IsAlive(name) {
+		return fmt.Errorf("process %s is not running", name)
+	}
+
+	// Ctrl+C stops the log follower, not us
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	cmd := exec.Command("bash", "-c", "clear && "+p.AttachCommand(name))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && len(interrupts) == 0 {
+		return err
+	}
+	return nil
+}
+
+// AttachCommand returns a command that follows the process log
+func (p *ProcessBackend) AttachCommand(name string) string {
+	return fmt.Sprintf("tail -n 100 -f %s", p.LogPath(name))
+}
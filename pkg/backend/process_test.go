@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or the timeout expires
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestProcessBackendLifecycle(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "worker.sh")
+	if err := os.WriteFile(script, []byte("echo started in $(pwd)\nsleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewProcessBackend(filepath.Join(dir, "state"))
+	name := ProcessName("software-engineer-1")
+	if b.IsAlive(name) {
+		t.Fatal("process reported alive before spawn")
+	}
+
+	if err := b.Spawn(name, script, dir); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	t.Cleanup(func() { b.Kill(name) })
+
+	if !b.IsAlive(name) {
+		t.Fatal("process not alive after spawn")
+	}
+	if err := b.Spawn(name, script, dir); err == nil {
+		t.Error("second Spawn of a running process succeeded")
+	}
+
+	var output string
+	if !waitFor(t, 5*time.Second, func() bool {
+		output, _ = b.CaptureOutput(name, 10)
+		return strings.Contains(output, "started in "+dir)
+	}) {
+		t.Errorf("CaptureOutput = %q, want the worker's output", output)
+	}
+
+	if err := b.Kill(name); err != nil {
+		t.Fatalf("Kill: %v", err)
+	}
+	if b.IsAlive(name) {
+		t.Error("process alive after Kill")
+	}
+	if err := b.Kill(name); err == nil {
+		t.Error("Kill of a stopped process succeeded")
+	}
+}
+
+func TestProcessBackendStateSharedAcrossInstances(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "worker.sh")
+	if err := os.WriteFile(script, []byte("sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	stateDir := filepath.Join(dir, "state")
+	name := ProcessName("intern-1")
+	if err := NewProcessBackend(stateDir).Spawn(name, script, dir); err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	// Another command (a new backend value) finds the worker through the pid file
+	other := NewProcessBackend(stateDir)
+	t.Cleanup(func() { other.Kill(name) })
+	if !other.IsAlive(name) {
+		t.Fatal("worker not visible to a second backend instance")
+	}
+	if err := other.Kill(name); err != nil {
+		t.Fatalf("Kill: %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{NameTmux, NameProcess} {
+		b, err := New(name, dir)
+		if err != nil {
+			t.Fatalf("New(%q): %v", name, err)
+		}
+		if b.Name() != name {
+			t.Errorf("New(%q).Name() = %q", name, b.Name())
+		}
+	}
+
+	if _, err := New("docker", dir); err == nil {
+		t.Error("New accepted an unknown backend")
+	}
+
+	if b, err := New("", dir); err != nil || b.Name() != Detect() {
+		t.Errorf("New(\"\") = %v, %v; want the detected backend %s", b, err, Detect())
+	}
+}
+
+func TestForSessionDefaultsToTmux(t *testing.T) {
+	if got := ForSession("", t.TempDir()).Name(); got != NameTmux {
+		t.Errorf("ForSession(\"\") = %s, want %s", got, NameTmux)
+	}
+	if got := ForSession(NameProcess, t.TempDir()).Name(); got != NameProcess {
+		t.Errorf("ForSession(process) = %s, want %s", got, NameProcess)
+	}
+}
@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// TmuxBackend runs each worker in its own detached tmux session
+type TmuxBackend struct{}
+
+// NewTmuxBackend creates a tmux backend
+func NewTmuxBackend() *TmuxBackend {
+	return &TmuxBackend{}
+}
+
+// Name returns the backend name
+func (t *TmuxBackend) Name() string {
+	return NameTmux
+}
+
+// Spawn creates a detached tmux session running the script
+func (t *TmuxBackend) Spawn(name, script, dir string) error {
+	cmd := exec.Command("tmux", "new-session", "-d", "-s", name, "-c", dir, "bash", script)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to start tmux session: %w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
+// IsAlive checks if the tmux session exists
+func (t *TmuxBackend) IsAlive(name string) bool {
+	cmd := exec.Command("tmux", "has-session", "-t", name)
+	return cmd.Run() == nil
+}
+
+// CaptureOutput captures the last n lines from the tmux pane
+func (t *TmuxBackend) CaptureOutput(name string, lines int) (string, error) {
+	cmd := exec.Command("tmux", "capture-pane", "-t", name, "-p", "-S", fmt.Sprintf("-%d", lines))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// Kill kills the tmux session
+func (t *TmuxBackend) Kill(name string) error {
+	return exec.Command("tmux", "kill-session", "-t", name).Run()
+}
+
+// Attach attaches the current terminal to the tmux session
+func (t *TmuxBackend) Attach(name string) error {
+	cmd := exec.Command("bash", "-c", "clear && "+t.AttachCommand(name))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// AttachCommand returns the tmux attach command
+func (t *TmuxBackend) AttachCommand(name string) string {
+	return fmt.Sprintf("tmux attach -t %s", name)
+}
@@ -26,6 +26,7 @@ type ExecutorOptions struct {
 	PersonaInstructions string
 	ExpandPrompt        bool
 	CustomSpecs         []string
+	Model               string // Passed to claude --model when set
 	Verbose             bool
 }
 
@@ -106,6 +107,10 @@ func (e *Executor) Run(opts ExecutorOptions) error {
 		args = append(args, "--spec", spec)
 	}
 
+	if opts.Model != "" {
+		args = append(args, "--model", opts.Model)
+	}
+
 	// Add the prompt
 	args = append(args, prompt)
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,6 +15,309 @@ type Config struct {
 	ClaudePath   string                 `yaml:"claude_path"`
 	Environments map[string]Environment `yaml:"environments"`
 	Templates    map[string]string      `yaml:"templates"`
+	Backend      string                 `yaml:"backend,omitempty"` // Worker process backend: tmux or process (default: auto-detect)
+	Restart      RestartConfig          `yaml:"restart,omitempty"`
+	Budget       BudgetConfig           `yaml:"budget,omitempty"`
+	Pricing      PricingConfig          `yaml:"pricing,omitempty"`
+	Merge        MergeConfig            `yaml:"merge,omitempty"`
+	Gates        GatesConfig            `yaml:"gates,omitempty"`
+	Approval     ApprovalConfig         `yaml:"approval,omitempty"`
+	Limits       LimitsConfig           `yaml:"limits,omitempty"`
+	Deadlines    DeadlinesConfig        `yaml:"deadlines,omitempty"`
+}
+
+// DeadlinesConfig controls reminders about task and request deadlines
+type DeadlinesConfig struct {
+	NudgeBefore time.Duration `yaml:"nudge_before,omitempty"` // How long before a deadline the owner is reminded (default 1h)
+}
+
+// NudgeWindow returns the configured reminder window or its default
+func (d DeadlinesConfig) NudgeWindow() time.Duration {
+	if d.NudgeBefore > 0 {
+		return d.NudgeBefore
+	}
+	return time.Hour
+}
+
+// Spawn queue orders
+const (
+	QueueFIFO     = "fifo"     // Oldest request first, unless its request.yaml sets a priority
+	QueuePriority = "priority" // Highest request or persona priority first, then oldest
+)
+
+// LimitsConfig caps how many sessions run at once. Requests beyond a limit
+// wait in the spawn queue until capacity frees up.
+type LimitsConfig struct {
+	MaxTotal   int            `yaml:"max_total,omitempty"`  // Active sessions across all persona types (0: unlimited)
+	Personas   map[string]int `yaml:"personas,omitempty"`   // Active sessions per persona type (0 or unset: unlimited)
+	Order      string         `yaml:"order,omitempty"`      // fifo (default) or priority
+	Priorities map[string]int `yaml:"priorities,omitempty"` // Queue priority per persona type (higher first)
+}
+
+// ApprovalConfig makes spawn requests wait for a human decision unless an
+// auto-approve rule accepts them
+type ApprovalConfig struct {
+	Enabled     bool             `yaml:"enabled,omitempty"`
+	AutoApprove AutoApproveRules `yaml:"auto_approve,omitempty"`
+}
+
+// AutoApproveRules accept a request when its persona type is listed and every
+// other set condition holds
+type AutoApproveRules struct {
+	Personas       []string `yaml:"personas,omitempty"`        // Persona types approved without asking ("*" for all)
+	MaxActive      int      `yaml:"max_active,omitempty"`      // Only while fewer sessions of the type are active
+	BudgetHeadroom float64  `yaml:"budget_headroom,omitempty"` // Only while at least this fraction of the team budget is left
+}
+
+// Covers reports whether the rules list a persona type
+func (r AutoApproveRules) Covers(personaType string) bool {
+	for _, p := range r.Personas {
+		if p == personaType || p == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// GatesConfig holds the completion gates every persona must pass and
+// per-persona overrides
+type GatesConfig struct {
+	Default  CompletionGate            `yaml:"default,omitempty"`
+	Personas map[string]CompletionGate `yaml:"personas,omitempty"` // Keyed by persona type; replaces the default
+}
+
+// CompletionGate is checked before a session whose tasks are all completed is
+// allowed to finish
+type CompletionGate struct {
+	Commands  []string      `yaml:"commands,omitempty"`   // Shell commands that must exit 0, e.g. "go test ./..."
+	QASignoff bool          `yaml:"qa_signoff,omitempty"` // Require a signoff message from a QA persona
+	Timeout   time.Duration `yaml:"timeout,omitempty"`    // Limit for each command (default 10m)
+}
+
+// IsSet reports whether the gate checks anything
+func (g CompletionGate) IsSet() bool {
+	return len(g.Commands) > 0 || g.QASignoff
+}
+
+// CommandTimeout returns the configured command timeout or its default
+func (g CompletionGate) CommandTimeout() time.Duration {
+	if g.Timeout > 0 {
+		return g.Timeout
+	}
+	return 10 * time.Minute
+}
+
+// GateFor returns the completion gate for a persona type
+func (c *Config) GateFor(personaType string) CompletionGate {
+	if gate, ok := c.Gates.Personas[personaType]; ok {
+		return gate
+	}
+	return c.Gates.Default
+}
+
+// MergeConfig controls how completed agent branches are integrated
+type MergeConfig struct {
+	Branch        string        `yaml:"branch,omitempty"`         // Integration branch (default: wildwest/integration)
+	Verify        string        `yaml:"verify,omitempty"`         // Shell command run after each merge, e.g. "go test ./..."
+	VerifyTimeout time.Duration `yaml:"verify_timeout,omitempty"` // Limit for the verify command (default 10m)
+	Manual        bool          `yaml:"manual,omitempty"`         // Only merge with "wildwest merge run", not on completion
+}
+
+// IntegrationBranch returns the configured integration branch or its default
+func (m MergeConfig) IntegrationBranch() string {
+	if m.Branch != "" {
+		return m.Branch
+	}
+	return "wildwest/integration"
+}
+
+// Timeout returns the configured verify timeout or its default
+func (m MergeConfig) Timeout() time.Duration {
+	if m.VerifyTimeout > 0 {
+		return m.VerifyTimeout
+	}
+	return 10 * time.Minute
+}
+
+// PricingConfig overrides and extends the built-in model pricing table
+type PricingConfig struct {
+	Models  map[string]ModelPrice `yaml:"models,omitempty"`  // Keyed by model family or full model ID
+	Aliases map[string]string     `yaml:"aliases,omitempty"` // Alias or model ID -> key in Models or a built-in family
+}
+
+// ModelPrice is the cost per million tokens for a model
+type ModelPrice struct {
+	Input      float64 `yaml:"input"`
+	Output     float64 `yaml:"output"`
+	CacheRead  float64 `yaml:"cache_read,omitempty"`
+	CacheWrite float64 `yaml:"cache_write,omitempty"`
+}
+
+// BudgetConfig caps what a team may spend. Limits apply to the whole team, to
+// all sessions of a persona type together, and to each individual session.
+type BudgetConfig struct {
+	Team     BudgetLimit            `yaml:"team,omitempty"`
+	Personas map[string]BudgetLimit `yaml:"personas,omitempty"` // Keyed by persona type
+	Session  BudgetLimit            `yaml:"session,omitempty"`
+	WarnAt   float64                `yaml:"warn_at,omitempty"`  // Fraction of a limit that triggers a warning (default 0.8)
+	PauseAt  float64                `yaml:"pause_at,omitempty"` // Fraction of a limit at which new spawns are paused (default 0.9)
+}
+
+// BudgetLimit is a spending cap in USD, tokens, or both. Zero means unlimited.
+type BudgetLimit struct {
+	USD    float64 `yaml:"usd,omitempty" json:"usd,omitempty"`
+	Tokens int64   `yaml:"tokens,omitempty" json:"tokens,omitempty"`
+}
+
+// IsSet reports whether the limit caps anything
+func (l BudgetLimit) IsSet() bool {
+	return l.USD > 0 || l.Tokens > 0
+}
+
+// Fraction returns how much of the limit the given spend uses (1.0 = exhausted).
+// When both USD and tokens are capped, the larger fraction wins.
+func (l BudgetLimit) Fraction(usd float64, tokens int64) float64 {
+	fraction := 0.0
+	if l.USD > 0 {
+		fraction = usd / l.USD
+	}
+	if l.Tokens > 0 {
+		if f := float64(tokens) / float64(l.Tokens); f > fraction {
+			fraction = f
+		}
+	}
+	return fraction
+}
+
+// WarnThreshold returns the configured warning fraction or its default
+func (b BudgetConfig) WarnThreshold() float64 {
+	if b.WarnAt > 0 {
+		return b.WarnAt
+	}
+	return 0.8
+}
+
+// PauseThreshold returns the configured pause fraction or its default
+func (b BudgetConfig) PauseThreshold() float64 {
+	if b.PauseAt > 0 {
+		return b.PauseAt
+	}
+	return 0.9
+}
+
+// Restart policies for crashed persona workers
+const (
+	RestartNever     = "never"      // Leave the session stopped
+	RestartOnFailure = "on-failure" // Restart unless all tasks are completed
+	RestartAlways    = "always"     // Restart whenever the worker exits
+)
+
+// RestartConfig holds the default restart policy and per-persona overrides
+type RestartConfig struct {
+	Default  RestartPolicy            `yaml:"default,omitempty"`
+	Personas map[string]RestartPolicy `yaml:"personas,omitempty"` // Keyed by persona type
+}
+
+// defaultMaxRetries is how often a crashed worker is restarted unless configured
+const defaultMaxRetries = 3
+
+// RestartPolicy controls whether and how often a crashed worker is respawned
+type RestartPolicy struct {
+	Policy     string        `yaml:"policy,omitempty"`      // never, on-failure, always
+	MaxRetries *int          `yaml:"max_retries,omitempty"` // Restarts before giving up (negative: unlimited, unset: 3)
+	Backoff    time.Duration `yaml:"backoff,omitempty"`     // Delay before the first restart, doubled each retry
+	MaxBackoff time.Duration `yaml:"max_backoff,omitempty"` // Upper bound for the restart delay
+}
+
+// RestartPolicyFor returns the effective restart policy for a persona type.
+// Unset fields fall back to the configured default, then to built-in values.
+func (c *Config) RestartPolicyFor(personaType string) RestartPolicy {
+	maxRetries := defaultMaxRetries
+	policy := RestartPolicy{
+		Policy:     RestartNever,
+		MaxRetries: &maxRetries,
+		Backoff:    10 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+	}
+	policy.merge(c.Restart.Default)
+	if override, ok := c.Restart.Personas[personaType]; ok {
+		policy.merge(override)
+	}
+	return policy
+}
+
+// merge overlays the set fields of other onto the policy
+func (p *RestartPolicy) merge(other RestartPolicy) {
+	if other.Policy != "" {
+		p.Policy = other.Policy
+	}
+	if other.MaxRetries != nil {
+		p.MaxRetries = other.MaxRetries
+	}
+	if other.Backoff > 0 {
+		p.Backoff = other.Backoff
+	}
+	if other.MaxBackoff > 0 {
+		p.MaxBackoff = other.MaxBackoff
+	}
+}
+
+// Delay returns how long to wait before the given restart attempt (0-based)
+func (p RestartPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 0; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// AllowsRestart reports whether another restart is permitted after the given
+// number of restarts. failed is false when the worker finished its tasks.
+func (p RestartPolicy) AllowsRestart(restarts int, failed bool) bool {
+	switch p.Policy {
+	case RestartAlways:
+	case RestartOnFailure:
+		if !failed {
+			return false
+		}
+	default:
+		return false
+	}
+	maxRetries := defaultMaxRetries
+	if p.MaxRetries != nil {
+		maxRetries = *p.MaxRetries
+	}
+	return maxRetries < 0 || restarts < maxRetries
+}
+
+// validate checks that the restart policy names a known policy
+func (p RestartPolicy) validate() error {
+	switch p.Policy {
+	case "", RestartNever, RestartOnFailure, RestartAlways:
+		return nil
+	}
+	return fmt.Errorf("unknown restart policy %q (expected %s, %s or %s)", p.Policy, RestartNever, RestartOnFailure, RestartAlways)
+}
+
+// validate checks the configured restart policies
+func (r RestartConfig) validate() error {
+	if err := r.Default.validate(); err != nil {
+		return fmt.Errorf("restart.default: %w", err)
+	}
+	personaTypes := make([]string, 0, len(r.Personas))
+	for personaType := range r.Personas {
+		personaTypes = append(personaTypes, personaType)
+	}
+	sort.Strings(personaTypes)
+	for _, personaType := range personaTypes {
+		if err := r.Personas[personaType].validate(); err != nil {
+			return fmt.Errorf("restart.personas.%s: %w", personaType, err)
+		}
+	}
+	return nil
 }
 
 // Environment represents a custom environment configuration
@@ -64,6 +369,9 @@ func LoadConfig(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if err := cfg.Restart.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
 
 	// Set default claude path if not specified
 	if cfg.ClaudePath == "" {
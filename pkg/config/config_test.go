@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a config file to a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wildwest.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRestartPolicyFor(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, `
+restart:
+  default:
+    policy: on-failure
+    backoff: 5s
+  personas:
+    intern:
+      max_retries: 0
+    qa:
+      policy: always
+      max_retries: -1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engineer := cfg.RestartPolicyFor("software-engineer")
+	if engineer.Policy != RestartOnFailure || engineer.Backoff != 5*time.Second || engineer.MaxBackoff != 5*time.Minute {
+		t.Errorf("engineer policy = %+v", engineer)
+	}
+	if !engineer.AllowsRestart(2, true) || engineer.AllowsRestart(3, true) {
+		t.Error("engineer policy does not default to 3 retries")
+	}
+	if engineer.AllowsRestart(0, false) {
+		t.Error("on-failure restarted a worker that finished its tasks")
+	}
+
+	// An explicit max_retries: 0 is kept rather than treated as unset
+	if cfg.RestartPolicyFor("intern").AllowsRestart(0, true) {
+		t.Error("max_retries: 0 allowed a restart")
+	}
+
+	qa := cfg.RestartPolicyFor("qa")
+	if !qa.AllowsRestart(100, false) {
+		t.Error("always with unlimited retries refused a restart")
+	}
+}
+
+func TestRestartPolicyDelay(t *testing.T) {
+	p := RestartPolicy{Backoff: 10 * time.Second, MaxBackoff: time.Minute}
+	for attempt, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if got := p.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestLoadConfigRejectsUnknownRestartPolicy(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, `
+restart:
+  personas:
+    intern:
+      policy: sometimes
+`))
+	if err == nil || !strings.Contains(err.Error(), "restart.personas.intern") || !strings.Contains(err.Error(), "sometimes") {
+		t.Errorf("LoadConfig error = %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Repo is a git repository identified by its top-level directory
+type Repo struct {
+	Root string
+}
+
+// FindRepo returns the repository containing dir
+func FindRepo(dir string) (*Repo, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	output, err := exec.Command("git", "-C", absDir, "rev-parse", "--show-toplevel").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s is not inside a git repository: %s", absDir, strings.TrimSpace(string(output)))
+	}
+	return &Repo{Root: strings.TrimSpace(string(output))}, nil
+}
+
+// run runs a git command in the repository and returns its trimmed output
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.Root}, args...)...)
+	output, err := cmd.CombinedOutput()
+	out := strings.TrimSpace(string(output))
+	if err != nil {
+		return out, fmt.Errorf("git %s failed: %w (output: %s)", args[0], err, out)
+	}
+	return out, nil
+}
+
+// CurrentBranch returns the checked out branch, or HEAD when detached
+func (r *Repo) CurrentBranch() (string, error) {
+	return r.run("rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// BranchExists reports whether a local branch exists
+func (r *Repo) BranchExists(branch string) bool {
+	_, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// AddWorktree checks out branch in a new worktree at path. The branch is
+// created from base unless it already exists.
+func (r *Repo) AddWorktree(path, branch, base string) error {
+	if r.BranchExists(branch) {
+		_, err := r.run("worktree", "add", path, branch)
+		return err
+	}
+	if base == "" {
+		base = "HEAD"
+	}
+	_, err := r.run("worktree", "add", "-b", branch, path, base)
+	return err
+}
+
+// RemoveWorktree removes a worktree, leaving its branch in place
+func (r *Repo) RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	if _, err := r.run(append(args, path)...); err != nil {
+		return err
+	}
+	_, err := r.run("worktree", "prune")
+	return err
+}
+
+// IsWorktree reports whether path is a checked out worktree
+func IsWorktree(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
+
+// HasChanges reports whether the worktree at path has uncommitted changes
+func HasChanges(path string) (bool, error) {
+	output, err := exec.Command("git", "-C", path, "status", "--porcelain").CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("git status failed: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
+// HasTrackedChanges reports whether tracked files in the repository have
+// uncommitted changes. Untracked files are ignored.
+func (r *Repo) HasTrackedChanges() (bool, error) {
+	output, err := r.run("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	return output != "", nil
+}
+
+// HeadCommit returns the abbreviated commit checked out in the repository
+func (r *Repo) HeadCommit() (string, error) {
+	return r.run("rev-parse", "--short", "HEAD")
+}
+
+// Merge merges branch into the checked out branch with a merge commit. On a
+// conflict the merge is aborted and the conflicting files are returned.
+func (r *Repo) Merge(branch, message string) ([]string, error) {
+	if _, err := r.run("merge", "--no-ff", "-m", message, branch); err != nil {
+		conflicts, _ := r.run("diff", "--name-only", "--diff-filter=U")
+		r.run("merge", "--abort")
+		if conflicts != "" {
+			return strings.Split(conflicts, "\n"), nil
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ResetKeep moves the checked out branch to ref. It fails rather than
+// overwrite uncommitted changes.
+func (r *Repo) ResetKeep(ref string) error {
+	_, err := r.run("reset", "--keep", ref)
+	return err
+}
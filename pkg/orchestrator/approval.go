@@ -0,0 +1,194 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// requestApproved reports whether a spawn request may be spawned. With approval
+// mode enabled, requests that no auto-approve rule accepts are queued in
+// orchestrator/requests.json until a human approves or denies them. A denied
+// request is removed and the reason sent to its requester.
+func (o *Orchestrator) requestApproved(dirName string, personaType session.SessionType) bool {
+	if !o.cfg.Approval.Enabled {
+		return true
+	}
+
+	requests, err := o.sm.LoadSpawnRequests()
+	if err != nil {
+		o.log("⚠️  Failed to load spawn requests: %v\n", err)
+		return false
+	}
+
+	var req *session.SpawnRequest
+	for _, r := range requests {
+		if r.ID == dirName {
+			req = r
+			break
+		}
+	}
+
+	if req == nil {
+		if reason, ok := o.autoApprove(personaType); ok {
+			o.log("\n✅ Auto-approved %s (%s)\n", dirName, reason)
+			return true
+		}
+		o.queueRequest(dirName, personaType)
+		return false
+	}
+
+	switch req.Status {
+	case session.RequestApproved:
+		// The entry is pruned once the request directory is consumed by the spawn
+		return true
+	case session.RequestDenied:
+		o.denyRequest(req)
+		return false
+	}
+	return false
+}
+
+// autoApprove checks a request against the auto-approve rules
+func (o *Orchestrator) autoApprove(personaType session.SessionType) (string, bool) {
+	rules := o.cfg.Approval.AutoApprove
+	if !rules.Covers(string(personaType)) {
+		return "", false
+	}
+
+	if rules.MaxActive > 0 {
+		active, err := o.sm.GetActiveSessions()
+		if err != nil {
+			return "", false
+		}
+		count := 0
+		for _, sess := range active {
+			if sess.PersonaType == personaType {
+				count++
+			}
+		}
+		if count >= rules.MaxActive {
+			return "", false
+		}
+	}
+
+	if rules.BudgetHeadroom > 0 && 1-o.teamBudgetUsed < rules.BudgetHeadroom {
+		return "", false
+	}
+
+	return fmt.Sprintf("auto-approve rule for %s", personaType), true
+}
+
+// queueRequest adds a request to the approval queue. The requester is resolved
+// the same way as at spawn time, so a denial reaches whoever asked.
+func (o *Orchestrator) queueRequest(dirName string, personaType session.SessionType) {
+	req := &session.SpawnRequest{
+		ID:          dirName,
+		PersonaType: personaType,
+		Requester:   o.resolveRequester(dirName, o.loadRequestMeta(dirName)),
+		Summary:     requestSummary(filepath.Join(o.workspacePath, dirName)),
+		RequestedAt: time.Now(),
+		Status:      session.RequestPending,
+	}
+
+	err := o.sm.UpdateSpawnRequests(func(requests []*session.SpawnRequest) ([]*session.SpawnRequest, error) {
+		return append(requests, req), nil
+	})
+	if err != nil {
+		o.log("⚠️  Failed to queue spawn request %s: %v\n", dirName, err)
+		return
+	}
+	o.log("\n📥 Spawn request %s is waiting for approval (wildwest requests approve %s)\n", dirName, dirName)
+}
+
+// dropRequest removes a handled request from the approval queue
+func (o *Orchestrator) dropRequest(dirName string) {
+	err := o.sm.UpdateSpawnRequests(func(requests []*session.SpawnRequest) ([]*session.SpawnRequest, error) {
+		kept := requests[:0]
+		for _, r := range requests {
+			if r.ID != dirName {
+				kept = append(kept, r)
+			}
+		}
+		return kept, nil
+	})
+	if err != nil {
+		o.log("⚠️  Failed to update spawn requests: %v\n", err)
+	}
+}
+
+// denyRequest removes a denied request directory and tells the requester why
+func (o *Orchestrator) denyRequest(req *session.SpawnRequest) {
+	reason := req.Reason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	o.log("\n🚫 Spawn request %s denied by %s: %s\n", req.ID, req.DecidedBy, reason)
+
+	if req.Requester != "" {
+		message := fmt.Sprintf("Your %s spawn request (%s) was denied by %s.\n\nReason: %s",
+			req.PersonaType, req.ID, req.DecidedBy, reason)
+		if err := o.sm.WriteInstructions("orchestrator", req.Requester, message); err != nil {
+			o.log("⚠️  Failed to notify %s: %v\n", req.Requester, err)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(o.workspacePath, req.ID)); err != nil {
+		o.log("⚠️  Failed to remove request directory: %v\n", err)
+	}
+	o.dropRequest(req.ID)
+}
+
+// requestSummary returns the first non-empty line of a request's instructions
+func requestSummary(requestDir string) string {
+	data, err := os.ReadFile(filepath.Join(requestDir, "instructions.md"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "# "))
+		if line != "" {
+			if runes := []rune(line); len(runes) > 100 {
+				line = string(runes[:97]) + "..."
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// pruneRequests drops queued requests whose directory no longer exists
+func (o *Orchestrator) pruneRequests() {
+	requests, err := o.sm.LoadSpawnRequests()
+	if err != nil || len(requests) == 0 {
+		return
+	}
+
+	stale := false
+	for _, r := range requests {
+		if _, err := os.Stat(filepath.Join(o.workspacePath, r.ID)); err != nil {
+			stale = true
+			break
+		}
+	}
+	if !stale {
+		return
+	}
+
+	err = o.sm.UpdateSpawnRequests(func(requests []*session.SpawnRequest) ([]*session.SpawnRequest, error) {
+		kept := requests[:0]
+		for _, r := range requests {
+			if _, err := os.Stat(filepath.Join(o.workspacePath, r.ID)); err == nil {
+				kept = append(kept, r)
+			}
+		}
+		return kept, nil
+	})
+	if err != nil {
+		o.log("⚠️  Failed to update spawn requests: %v\n", err)
+	}
+}
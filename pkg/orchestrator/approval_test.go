@@ -0,0 +1,149 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// newApprovalOrchestrator creates a test orchestrator with approval mode on
+func newApprovalOrchestrator(t *testing.T, rules config.AutoApproveRules) *Orchestrator {
+	t.Helper()
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Approval = config.ApprovalConfig{Enabled: true, AutoApprove: rules}
+	return o
+}
+
+// writeInstructions gives a request directory its instructions.md
+func writeInstructions(t *testing.T, o *Orchestrator, dirName, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(o.workspacePath, dirName, "instructions.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// queuedRequest returns the approval queue entry for a request directory
+func queuedRequest(t *testing.T, o *Orchestrator, dirName string) *session.SpawnRequest {
+	t.Helper()
+	requests, err := o.sm.LoadSpawnRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range requests {
+		if req.ID == dirName {
+			return req
+		}
+	}
+	return nil
+}
+
+func TestDeniedRequestNotifiesRequesterFoundInInstructions(t *testing.T) {
+	o := newApprovalOrchestrator(t, config.AutoApproveRules{})
+	manager, err := o.sm.CreateSession(session.SessionTypeEngineeringManager, "", o.workspacePath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No request.yaml or requester file; only the instructions name the requester
+	dirName := "qa-request-1"
+	writeRequest(t, o, dirName, "")
+	writeInstructions(t, o, dirName, "# Test the login flow\n\nRequested by "+manager.ID+"\n")
+
+	if o.requestApproved(dirName, session.SessionTypeQA) {
+		t.Fatal("request approved without a decision")
+	}
+	req := queuedRequest(t, o, dirName)
+	if req == nil || req.Status != session.RequestPending {
+		t.Fatalf("queued request = %+v, want pending", req)
+	}
+	if req.Requester != manager.ID || req.Summary != "Test the login flow" {
+		t.Errorf("requester = %q, summary = %q", req.Requester, req.Summary)
+	}
+
+	if _, err := o.sm.DecideSpawnRequest(dirName, session.RequestDenied, "QA is covered", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if o.requestApproved(dirName, session.SessionTypeQA) {
+		t.Fatal("denied request approved")
+	}
+
+	if _, err := os.Stat(filepath.Join(o.workspacePath, dirName)); !os.IsNotExist(err) {
+		t.Errorf("denied request directory still exists (err = %v)", err)
+	}
+	if queuedRequest(t, o, dirName) != nil {
+		t.Error("denied request still queued")
+	}
+	inbox, err := o.sm.ReadInbox(manager.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox) != 1 || !strings.Contains(inbox[0].Content, "denied by alice") || !strings.Contains(inbox[0].Content, "QA is covered") {
+		t.Errorf("requester inbox = %+v, want the denial", inbox)
+	}
+}
+
+func TestApprovedRequestSpawns(t *testing.T) {
+	o := newApprovalOrchestrator(t, config.AutoApproveRules{})
+	dirName := "qa-request-1"
+	writeRequest(t, o, dirName, "")
+	writeInstructions(t, o, dirName, "Test it\n")
+
+	if o.requestApproved(dirName, session.SessionTypeQA) {
+		t.Fatal("request approved without a decision")
+	}
+	if _, err := o.sm.DecideSpawnRequest("qa-request", session.RequestApproved, "", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if !o.requestApproved(dirName, session.SessionTypeQA) {
+		t.Error("approved request not allowed to spawn")
+	}
+}
+
+func TestAutoApproveRules(t *testing.T) {
+	o := newApprovalOrchestrator(t, config.AutoApproveRules{
+		Personas:       []string{string(session.SessionTypeQA)},
+		MaxActive:      1,
+		BudgetHeadroom: 0.25,
+	})
+
+	if _, ok := o.autoApprove(session.SessionTypeQA); !ok {
+		t.Error("listed persona type not auto-approved")
+	}
+	if _, ok := o.autoApprove(session.SessionTypeSoftwareEngineer); ok {
+		t.Error("unlisted persona type auto-approved")
+	}
+
+	o.teamBudgetUsed = 0.8
+	if _, ok := o.autoApprove(session.SessionTypeQA); ok {
+		t.Error("auto-approved with less budget headroom than required")
+	}
+	o.teamBudgetUsed = 0
+
+	if _, err := o.sm.CreateSession(session.SessionTypeQA, "", o.workspacePath, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.autoApprove(session.SessionTypeQA); ok {
+		t.Error("auto-approved beyond max_active")
+	}
+}
+
+func TestRequestSummaryTruncatesByRune(t *testing.T) {
+	dir := t.TempDir()
+	title := strings.Repeat("é", 120)
+	if err := os.WriteFile(filepath.Join(dir, "instructions.md"), []byte("\n# "+title+"\n\nDetails\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := requestSummary(dir)
+	if !utf8.ValidString(summary) {
+		t.Fatalf("summary is not valid UTF-8: %q", summary)
+	}
+	if want := strings.Repeat("é", 97) + "..."; summary != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+}
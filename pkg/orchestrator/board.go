@@ -0,0 +1,132 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// claimingPersonas are the persona types offered unassigned board tasks when idle
+var claimingPersonas = []session.SessionType{
+	session.SessionTypeSoftwareEngineer,
+	session.SessionTypeIntern,
+}
+
+// boardOfferInterval is how long an untaken board task waits before it is
+// offered to idle personas again
+const boardOfferInterval = 15 * time.Minute
+
+// processBoard syncs the task board with the persona task lists and offers
+// unassigned tasks to idle personas
+func (o *Orchestrator) processBoard() error {
+	if err := o.sm.SyncBoard(); err != nil {
+		return err
+	}
+
+	board, err := o.sm.LoadBoard()
+	if err != nil {
+		return err
+	}
+
+	var open []session.Task
+	for _, task := range board.Tasks {
+		if !task.Claimable() {
+			delete(o.offeredTasks, task.ID)
+			continue
+		}
+		if time.Since(o.offeredTasks[task.ID]) >= boardOfferInterval {
+			open = append(open, task)
+		}
+	}
+	if len(open) == 0 {
+		return nil
+	}
+
+	idle, err := o.idleClaimers()
+	if err != nil || len(idle) == 0 {
+		return err
+	}
+
+	var lines []string
+	for _, task := range open {
+		lines = append(lines, fmt.Sprintf("- %s: %s", task.ID, task.Description))
+		o.offeredTasks[task.ID] = time.Now()
+	}
+
+	absWorkspace, _ := filepath.Abs(o.workspacePath)
+	for _, sess := range idle {
+		o.log("\n📌 Offering %d board task(s) to %s (%s)\n", len(open), sess.PersonaName, sess.ID)
+		message := fmt.Sprintf("Unassigned tasks are waiting on the shared task board:\n\n%s\n\nIf you are free, claim one - it will be added to your tasks.md:\n\nwildwest task claim <task-id> --as %s --workspace %s",
+			strings.Join(lines, "\n"), sess.ID, absWorkspace)
+		if err := o.sm.WriteInstructions("orchestrator", sess.ID, message); err != nil {
+			o.log("⚠️  Failed to offer tasks to %s: %v\n", sess.ID, err)
+		}
+	}
+	return nil
+}
+
+// assignBoardTask gives a session that finished its own tasks the next
+// unassigned board task instead of completing it. A stopped worker is
+// restarted to work on the task. It reports whether a task was assigned.
+func (o *Orchestrator) assignBoardTask(sess *session.Session) bool {
+	if !isClaimingPersona(sess.PersonaType) {
+		return false
+	}
+
+	task, err := o.sm.ClaimBoardTask("", sess.ID)
+	if err != nil {
+		// No unassigned tasks on the board
+		return false
+	}
+	delete(o.offeredTasks, task.ID)
+
+	o.log("\n📌 Assigned board task %s to %s (%s): %s\n", task.ID, sess.PersonaName, sess.ID, task.Description)
+	message := fmt.Sprintf("You have finished your tasks, so board task %s (%s) has been assigned to you and added to your tasks.md. Work on it and mark it completed when done.",
+		task.ID, task.Description)
+	if err := o.sm.WriteInstructions("orchestrator", sess.ID, message); err != nil {
+		o.log("   ⚠️  Failed to notify %s: %v\n", sess.ID, err)
+	}
+
+	if !o.activeSessions[sess.ID] {
+		if err := o.sm.RecordExit(sess.ID, "board task assigned", "resuming"); err != nil {
+			o.log("   ⚠️  Failed to schedule resume of %s: %v\n", sess.ID, err)
+		}
+	}
+	return true
+}
+
+// idleClaimers returns active personas that may claim board tasks and have no
+// unfinished tasks of their own
+func (o *Orchestrator) idleClaimers() ([]*session.Session, error) {
+	sessions, err := o.sm.GetActiveSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var idle []*session.Session
+	for _, sess := range sessions {
+		if !o.activeSessions[sess.ID] || !isClaimingPersona(sess.PersonaType) {
+			continue
+		}
+		tasks, err := o.sm.LoadTasks(sess.ID)
+		if err != nil {
+			continue
+		}
+		if tasks.Count(session.TaskStatusCompleted) == len(tasks.Tasks) {
+			idle = append(idle, sess)
+		}
+	}
+	return idle, nil
+}
+
+func isClaimingPersona(personaType session.SessionType) bool {
+	for _, t := range claimingPersonas {
+		if t == personaType {
+			return true
+		}
+	}
+	return false
+}
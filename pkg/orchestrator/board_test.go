@@ -0,0 +1,88 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// finishedEngineer creates an engineer whose only task is completed
+func finishedEngineer(t *testing.T, o *Orchestrator) *session.Session {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond) // Session IDs are millisecond timestamps
+	sess, err := o.sm.CreateSession(session.SessionTypeSoftwareEngineer, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.sm.AddTask(sess.ID, "Own work", "system"); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.sm.SetTaskStatus(sess.ID, "T1", session.TaskStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestCompleteIfDoneAssignsBoardTask(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	sess := finishedEngineer(t, o)
+	if _, err := o.sm.AddBoardTask("Write integration tests", "system", "", nil, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	o.completeIfDone(sess)
+
+	got, err := o.sm.GetSession(sess.ID)
+	if err != nil {
+		t.Fatalf("session archived instead of given the board task: %v", err)
+	}
+	if got.Status != "resuming" {
+		t.Errorf("status = %q, want resuming", got.Status)
+	}
+	tasks, _ := o.sm.LoadTasks(sess.ID)
+	if task := tasks.Find("B1"); task == nil || task.Status != session.TaskStatusInProgress {
+		t.Errorf("tasks after assignment = %+v", tasks.Tasks)
+	}
+	inbox, _ := o.sm.ReadInbox(sess.ID, true)
+	if len(inbox) != 1 || !strings.Contains(inbox[0].Content, "B1") {
+		t.Errorf("inbox = %+v", inbox)
+	}
+}
+
+func TestProcessBoardRepeatsUntakenOffers(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	sess, err := o.sm.CreateSession(session.SessionTypeIntern, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.activeSessions[sess.ID] = true
+	if _, err := o.sm.AddBoardTask("Update docs", "system", "", nil, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	offers := func() int {
+		inbox, _ := o.sm.ReadInbox(sess.ID, false)
+		return len(inbox)
+	}
+
+	if err := o.processBoard(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.processBoard(); err != nil {
+		t.Fatal(err)
+	}
+	if n := offers(); n != 1 {
+		t.Fatalf("got %d offers, want 1 within the offer interval", n)
+	}
+
+	o.offeredTasks["B1"] = time.Now().Add(-boardOfferInterval)
+	if err := o.processBoard(); err != nil {
+		t.Fatal(err)
+	}
+	if n := offers(); n != 2 {
+		t.Errorf("got %d offers, want the untaken task offered again", n)
+	}
+}
@@ -0,0 +1,236 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// StatusBudgetExceeded marks sessions stopped because a budget ran out
+const StatusBudgetExceeded = "budget-exceeded"
+
+// budgetFile holds the per-workspace team budget set by "team start --budget"
+const budgetFile = "budget.json"
+
+// SaveBudget stores a team budget for a single workspace. It overrides the
+// team limit from the config file for that workspace only.
+func SaveBudget(workspacePath string, limit config.BudgetLimit) error {
+	data, err := json.MarshalIndent(limit, "", "  ")
+	if err != nil {
+		return err
+	}
+	return session.WriteFileAtomic(filepath.Join(workspacePath, budgetFile), data, 0644)
+}
+
+// LoadBudget returns the workspace's team budget, or a zero limit if none is set
+func LoadBudget(workspacePath string) (config.BudgetLimit, error) {
+	var limit config.BudgetLimit
+	data, err := os.ReadFile(filepath.Join(workspacePath, budgetFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return limit, nil
+		}
+		return limit, err
+	}
+	err = json.Unmarshal(data, &limit)
+	return limit, err
+}
+
+// budgetConfig returns the effective budgets: the config file with the
+// workspace team budget applied on top
+func (o *Orchestrator) budgetConfig() config.BudgetConfig {
+	budget := o.cfg.Budget
+	if limit, err := LoadBudget(o.workspacePath); err == nil && limit.IsSet() {
+		budget.Team = limit
+	}
+	return budget
+}
+
+// spend is the cost and token usage accumulated against a budget
+type spend struct {
+	usd    float64
+	tokens int64
+}
+
+func (s *spend) add(usage *session.TokenUsage) {
+	if usage == nil {
+		return
+	}
+	s.usd += usage.EstimatedCost
+	s.tokens += usage.TotalTokens
+}
+
+func (s spend) String() string {
+	return fmt.Sprintf("%s, %s tokens", session.FormatCost(s.usd), session.FormatTokens(s.tokens))
+}
+
+// enforceBudgets compares recorded spend against the configured budgets. It warns
+// once per limit at the warning threshold, pauses spawning at the pause threshold,
+// and kills the affected sessions once a limit is exhausted.
+func (o *Orchestrator) enforceBudgets() error {
+	budget := o.budgetConfig()
+	o.teamBudgetUsed = 0
+
+	// Completed and archived sessions still count against team and persona budgets
+	history, err := o.sm.GetSessionHistory()
+	if err != nil {
+		return err
+	}
+	if !budget.Team.IsSet() && !budget.Session.IsSet() && len(budget.Personas) == 0 && !hasRequestBudgets(history) {
+		return nil
+	}
+
+	// Spend comes from the same usage totals "team cost" and the TUI show
+	_, usage, err := o.sm.GetTotalTeamCost()
+	if err != nil {
+		return err
+	}
+
+	var team spend
+	byPersona := make(map[string]*spend)
+	for _, sess := range history {
+		team.add(usage[sess.ID])
+		personaType := string(sess.PersonaType)
+		if byPersona[personaType] == nil {
+			byPersona[personaType] = &spend{}
+		}
+		byPersona[personaType].add(usage[sess.ID])
+	}
+
+	o.pausedPersonas = make(map[string]bool)
+	o.spawningPaused = ""
+
+	// Team budget
+	if budget.Team.IsSet() {
+		fraction := budget.Team.Fraction(team.usd, team.tokens)
+		o.teamBudgetUsed = fraction
+		o.checkBudget("team", fraction, budget, team)
+		if fraction >= 1 {
+			o.spawningPaused = fmt.Sprintf("team budget exceeded (%s)", team)
+			o.stopSessions(history, func(*session.Session) bool { return true }, o.spawningPaused)
+			return nil
+		}
+		if fraction >= budget.PauseThreshold() {
+			o.spawningPaused = fmt.Sprintf("team budget %.0f%% used (%s)", fraction*100, team)
+		}
+	}
+
+	// Persona type budgets
+	for personaType, limit := range budget.Personas {
+		used := byPersona[personaType]
+		if used == nil || !limit.IsSet() {
+			continue
+		}
+		fraction := limit.Fraction(used.usd, used.tokens)
+		o.checkBudget(personaType, fraction, budget, *used)
+		if fraction >= budget.PauseThreshold() {
+			o.pausedPersonas[personaType] = true
+		}
+		if fraction >= 1 {
+			reason := fmt.Sprintf("%s budget exceeded (%s)", personaType, used)
+			o.stopSessions(history, func(s *session.Session) bool {
+				return string(s.PersonaType) == personaType
+			}, reason)
+		}
+	}
+
+	// Per-session budget; a budget set in the session's spawn request replaces it
+	for _, sess := range history {
+		limit := sessionLimit(sess, budget.Session)
+		if !limit.IsSet() {
+			continue
+		}
+		var used spend
+		used.add(usage[sess.ID])
+		fraction := limit.Fraction(used.usd, used.tokens)
+		o.checkBudget("session "+sess.ID, fraction, budget, used)
+		if fraction >= 1 {
+			reason := fmt.Sprintf("session budget exceeded (%s)", used)
+			o.stopSessions(history, func(s *session.Session) bool { return s.ID == sess.ID }, reason)
+		}
+	}
+
+	return nil
+}
+
+// sessionLimit returns the budget from a session's spawn request, or the
+// configured per-session budget
+func sessionLimit(sess *session.Session, configured config.BudgetLimit) config.BudgetLimit {
+	if sess.BudgetUSD > 0 || sess.BudgetTokens > 0 {
+		return config.BudgetLimit{USD: sess.BudgetUSD, Tokens: sess.BudgetTokens}
+	}
+	return configured
+}
+
+// hasRequestBudgets reports whether any session has a budget from its spawn request
+func hasRequestBudgets(sessions []*session.Session) bool {
+	for _, sess := range sessions {
+		if sess.BudgetUSD > 0 || sess.BudgetTokens > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBudget logs a warning the first time a budget crosses each threshold
+func (o *Orchestrator) checkBudget(name string, fraction float64, budget config.BudgetConfig, used spend) {
+	level := ""
+	switch {
+	case fraction >= 1:
+		level = "exceeded"
+	case fraction >= budget.PauseThreshold():
+		level = "pause"
+	case fraction >= budget.WarnThreshold():
+		level = "warn"
+	default:
+		return
+	}
+
+	key := name + ":" + level
+	if o.budgetWarnings[key] {
+		return
+	}
+	o.budgetWarnings[key] = true
+
+	switch level {
+	case "exceeded":
+		o.log("\n🛑 Budget exceeded for %s: %.0f%% used (%s)\n", name, fraction*100, used)
+	case "pause":
+		o.log("\n⏸️  Budget for %s at %.0f%% (%s), pausing new spawns\n", name, fraction*100, used)
+	case "warn":
+		o.log("\n💸 Budget warning for %s: %.0f%% used (%s)\n", name, fraction*100, used)
+	}
+}
+
+// stopSessions kills the running sessions matching match and marks them budget-exceeded
+func (o *Orchestrator) stopSessions(sessions []*session.Session, match func(*session.Session) bool, reason string) {
+	for _, sess := range sessions {
+		if !match(sess) || !sess.Running() {
+			continue
+		}
+
+		// Archived directories no longer hold a running session
+		if _, err := os.Stat(filepath.Join(o.workspacePath, sess.ID, "session.json")); err != nil {
+			continue
+		}
+
+		if o.isSessionRunning(sess.ID) {
+			o.backendFor(sess.ID).Kill(backend.ProcessName(sess.ID))
+		}
+		delete(o.activeSessions, sess.ID)
+
+		o.log("   💀 Stopped %s (%s): %s\n", sess.PersonaName, sess.ID, reason)
+		o.sm.RecordExit(sess.ID, reason, StatusBudgetExceeded)
+		sess.Status = StatusBudgetExceeded
+	}
+}
+
+// spawnAllowed reports whether new sessions of a persona type may be spawned
+func (o *Orchestrator) spawnAllowed(personaType session.SessionType) bool {
+	return o.spawningPaused == "" && !o.pausedPersonas[string(personaType)]
+}
@@ -0,0 +1,111 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// newBudgetOrchestrator creates a test orchestrator that can stop sessions
+func newBudgetOrchestrator(t *testing.T, budget config.BudgetConfig) *Orchestrator {
+	t.Helper()
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Budget = budget
+	o.backend = backend.NewProcessBackend(t.TempDir())
+	return o
+}
+
+// spendingSession creates a session whose usage log records costUSD
+func spendingSession(t *testing.T, o *Orchestrator, personaType session.SessionType, costUSD string) *session.Session {
+	t.Helper()
+	sess, err := o.sm.CreateSession(personaType, "", o.workspacePath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := `{"model":"claude-sonnet-4-5","input_tokens":100,"output_tokens":50,"cost_usd":` + costUSD + "}\n"
+	if err := os.WriteFile(o.sm.UsageLogPath(sess.ID), []byte(record), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestCompletedSessionsCountInSummaryAndBudget(t *testing.T) {
+	o := newBudgetOrchestrator(t, config.BudgetConfig{})
+	if err := SaveBudget(o.workspacePath, config.BudgetLimit{USD: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	spendingSession(t, o, session.SessionTypeQA, "0.25")
+	done := spendingSession(t, o, session.SessionTypeSoftwareEngineer, "0.75")
+	if err := os.Rename(filepath.Join(o.workspacePath, done.ID), filepath.Join(o.workspacePath, done.ID+"-completed")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := o.enforceBudgets(); err != nil {
+		t.Fatal(err)
+	}
+	if o.teamBudgetUsed != 0.5 {
+		t.Errorf("team budget used = %v, want 0.5", o.teamBudgetUsed)
+	}
+
+	summary, err := NewCostMonitor(o.sm).GetCurrentCostSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{done.ID, "Total Team Cost: " + session.FormatCost(1), "Team Budget: 50% used"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary is missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestPersonaBudgetPausesSpawning(t *testing.T) {
+	o := newBudgetOrchestrator(t, config.BudgetConfig{
+		Personas: map[string]config.BudgetLimit{string(session.SessionTypeQA): {USD: 1}},
+	})
+	spendingSession(t, o, session.SessionTypeQA, "0.95")
+
+	if err := o.enforceBudgets(); err != nil {
+		t.Fatal(err)
+	}
+	if o.spawnAllowed(session.SessionTypeQA) {
+		t.Error("QA spawns allowed at 95% of its budget")
+	}
+	if !o.spawnAllowed(session.SessionTypeSoftwareEngineer) {
+		t.Error("a QA budget paused software engineer spawns")
+	}
+	if !o.budgetWarnings["qa:pause"] {
+		t.Errorf("pause warning not recorded: %v", o.budgetWarnings)
+	}
+}
+
+func TestRequestBudgetStopsOnlyThatSession(t *testing.T) {
+	o := newBudgetOrchestrator(t, config.BudgetConfig{})
+	over := spendingSession(t, o, session.SessionTypeSoftwareEngineer, "0.60")
+	under := spendingSession(t, o, session.SessionTypeQA, "0.60")
+	if err := o.sm.UpdateSession(over.ID, func(s *session.Session) { s.BudgetUSD = 0.5 }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := o.enforceBudgets(); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]string{over.ID: StatusBudgetExceeded, under.ID: "active"} {
+		sess, err := o.sm.GetSession(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sess.Status != want {
+			t.Errorf("session %s status = %q, want %q", id, sess.Status, want)
+		}
+	}
+	if o.spawningPaused != "" {
+		t.Errorf("a session budget paused all spawning: %s", o.spawningPaused)
+	}
+}
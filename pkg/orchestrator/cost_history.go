@@ -0,0 +1,202 @@
+package orchestrator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// costHistoryFile is the time series of per-session cost snapshots, one JSON
+// record per line, relative to the workspace
+const costHistoryFile = "orchestrator/cost-history.jsonl"
+
+// DefaultBurnRateWindow is how far back burn rates look by default
+const DefaultBurnRateWindow = time.Hour
+
+// CostSnapshot is a session's cumulative usage at a point in time
+type CostSnapshot struct {
+	Timestamp   time.Time `json:"timestamp"`
+	SessionID   string    `json:"session_id"`
+	PersonaType string    `json:"persona_type"`
+	TotalTokens int64     `json:"total_tokens"`
+	Cost        float64   `json:"cost"`
+}
+
+// CostHistory is a workspace's cost snapshots in chronological order
+type CostHistory []CostSnapshot
+
+// AppendCostSnapshots adds snapshots to the workspace's cost history
+func AppendCostSnapshots(workspacePath string, snapshots []CostSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	path := filepath.Join(workspacePath, costHistoryFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	for _, snapshot := range snapshots {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(buf.String())
+	return err
+}
+
+// LoadCostHistory reads the workspace's cost history, oldest first
+func LoadCostHistory(workspacePath string) (CostHistory, error) {
+	file, err := os.Open(filepath.Join(workspacePath, costHistoryFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var history CostHistory
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var snapshot CostSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			continue
+		}
+		history = append(history, snapshot)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+	return history, scanner.Err()
+}
+
+// Latest returns the most recent snapshot of each session
+func (h CostHistory) Latest() map[string]CostSnapshot {
+	latest := make(map[string]CostSnapshot)
+	for _, snapshot := range h {
+		latest[snapshot.SessionID] = snapshot
+	}
+	return latest
+}
+
+// TotalAt returns the team's cumulative cost as of t
+func (h CostHistory) TotalAt(t time.Time) float64 {
+	return h.personaTotalAt(t, "")
+}
+
+// personaTotalAt returns the cumulative cost of one persona type (or the whole
+// team when personaType is empty) as of t
+func (h CostHistory) personaTotalAt(t time.Time, personaType string) float64 {
+	latest := make(map[string]float64)
+	for _, snapshot := range h {
+		if snapshot.Timestamp.After(t) {
+			break
+		}
+		if personaType == "" || snapshot.PersonaType == personaType {
+			latest[snapshot.SessionID] = snapshot.Cost
+		}
+	}
+
+	total := 0.0
+	for _, cost := range latest {
+		total += cost
+	}
+	return total
+}
+
+// BurnRate returns the spend per hour over the window ending at now. If the
+// history is shorter than the window, the rate covers the history instead.
+func (h CostHistory) BurnRate(window time.Duration, now time.Time) float64 {
+	return h.personaBurnRate(window, now, "")
+}
+
+func (h CostHistory) personaBurnRate(window time.Duration, now time.Time, personaType string) float64 {
+	if len(h) < 2 {
+		return 0
+	}
+
+	start := now.Add(-window)
+	if first := h[0].Timestamp; first.After(start) {
+		start = first
+	}
+	elapsed := now.Sub(start)
+	if elapsed < time.Minute {
+		return 0
+	}
+
+	spent := h.personaTotalAt(now, personaType) - h.personaTotalAt(start, personaType)
+	return spent / elapsed.Hours()
+}
+
+// HourlyCost returns the team spend in each clock hour covered by the history
+func (h CostHistory) HourlyCost() ([]time.Time, []float64) {
+	if len(h) == 0 {
+		return nil, nil
+	}
+
+	var hours []time.Time
+	var costs []float64
+	first := h[0].Timestamp.Truncate(time.Hour)
+	last := h[len(h)-1].Timestamp.Truncate(time.Hour)
+	previous := h.TotalAt(first.Add(-time.Nanosecond))
+	for hour := first; !hour.After(last); hour = hour.Add(time.Hour) {
+		total := h.TotalAt(hour.Add(time.Hour - time.Nanosecond))
+		hours = append(hours, hour)
+		costs = append(costs, total-previous)
+		previous = total
+	}
+	return hours, costs
+}
+
+// PersonaTypes returns the persona types in the history, sorted
+func (h CostHistory) PersonaTypes() []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, snapshot := range h {
+		if !seen[snapshot.PersonaType] {
+			seen[snapshot.PersonaType] = true
+			types = append(types, snapshot.PersonaType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// FormatBurnRate formats a spend per hour
+func FormatBurnRate(rate float64) string {
+	return fmt.Sprintf("%s/h", session.FormatCost(rate))
+}
+
+// formatDuration formats a duration to the minute, dropping zero units (8h,
+// 1h30m). Durations that round to under a minute are shown in seconds (45s).
+func formatDuration(d time.Duration) string {
+	if d.Round(time.Minute) == 0 {
+		return d.Round(time.Second).String()
+	}
+	s := d.Round(time.Minute).String()
+	s = strings.TrimSuffix(s, "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
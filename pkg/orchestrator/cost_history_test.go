@@ -0,0 +1,46 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                            "0s",
+		20 * time.Second:             "20s",
+		45 * time.Second:             "1m",
+		5 * time.Minute:              "5m",
+		8 * time.Hour:                "8h",
+		90 * time.Minute:             "1h30m",
+		2*time.Hour + 10*time.Second: "2h",
+		time.Hour + 59*time.Minute + 40*time.Second: "2h",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestBurnRate(t *testing.T) {
+	start := time.Date(2024, 1, 26, 10, 0, 0, 0, time.UTC)
+	history := CostHistory{
+		{Timestamp: start, SessionID: "a", PersonaType: "software-engineer", Cost: 1},
+		{Timestamp: start.Add(30 * time.Minute), SessionID: "b", PersonaType: "qa", Cost: 0.5},
+		{Timestamp: start.Add(time.Hour), SessionID: "a", PersonaType: "software-engineer", Cost: 3},
+	}
+
+	if got := history.TotalAt(start.Add(time.Hour)); got != 3.5 {
+		t.Errorf("TotalAt = %v, want 3.5", got)
+	}
+	if got := history.BurnRate(time.Hour, start.Add(time.Hour)); got != 2.5 {
+		t.Errorf("BurnRate = %v, want 2.5/h", got)
+	}
+	if got := history.personaBurnRate(time.Hour, start.Add(time.Hour), "qa"); got != 0.5 {
+		t.Errorf("qa burn rate = %v, want 0.5/h", got)
+	}
+	if got := (CostHistory{history[0]}).BurnRate(time.Hour, start); got != 0 {
+		t.Errorf("burn rate of a single snapshot = %v, want 0", got)
+	}
+}
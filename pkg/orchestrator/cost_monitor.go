@@ -2,18 +2,38 @@ package orchestrator
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
 	"github.com/tarzzz/wildwest/pkg/session"
 )
 
+// PricingTable returns the built-in pricing with the config file's models and
+// aliases applied
+func PricingTable(cfg *config.Config) *session.PricingTable {
+	table := session.DefaultPricing()
+	for model, price := range cfg.Pricing.Models {
+		table.Set(model, session.ModelPricing{
+			InputPer1M:      price.Input,
+			OutputPer1M:     price.Output,
+			CacheReadPer1M:  price.CacheRead,
+			CacheWritePer1M: price.CacheWrite,
+		})
+	}
+	for alias, model := range cfg.Pricing.Aliases {
+		table.Alias(alias, model)
+	}
+	return table
+}
+
 // CostMonitor handles periodic token usage polling and cost tracking
 type CostMonitor struct {
 	sm            *session.SessionManager
 	pollInterval  time.Duration
 	activeSessions map[string]bool
+	lastSnapshots map[string]CostSnapshot // Last recorded snapshot per session
 }
 
 // NewCostMonitor creates a new cost monitor
@@ -33,6 +53,11 @@ func (cm *CostMonitor) Start() {
 	fmt.Println("💰 Cost Monitor Started")
 	fmt.Printf("   Polling interval: %v\n\n", cm.pollInterval)
 
+	// Continue the existing history rather than re-recording unchanged sessions
+	if history, err := LoadCostHistory(cm.sm.GetWorkspacePath()); err == nil {
+		cm.lastSnapshots = history.Latest()
+	}
+
 	// Initial scan
 	cm.pollAllSessions()
 
@@ -44,27 +69,44 @@ func (cm *CostMonitor) Start() {
 	}
 }
 
-// pollAllSessions polls all active tmux sessions for token usage
+// pollAllSessions syncs token usage for all active sessions from their usage
+// logs, falling back to scraping worker output for workers without one, and
+// records the results in the cost history
 func (cm *CostMonitor) pollAllSessions() {
 	sessions, err := cm.sm.GetAllSessions()
 	if err != nil {
 		return
 	}
 
+	cm.syncSessions(sessions)
+	cm.recordHistory(sessions)
+}
+
+// syncSessions updates the token usage of active sessions
+func (cm *CostMonitor) syncSessions(sessions []*session.Session) {
 	for _, sess := range sessions {
 		// Only poll active sessions
 		if sess.Status != "active" {
 			continue
 		}
 
-		// Check if tmux session exists
-		tmuxSessionName := fmt.Sprintf("claude-%s", sess.ID)
-		if !cm.isTmuxSessionRunning(tmuxSessionName) {
+		// Workers running Claude with JSON output log exact usage
+		if found, err := cm.sm.SyncTokenUsage(sess.ID); err != nil {
+			fmt.Printf("⚠️  Failed to sync token usage for %s: %v\n", sess.ID, err)
+			continue
+		} else if found {
+			continue
+		}
+
+		// Check if worker process exists
+		b := backend.ForSession(sess.Backend, cm.sm.GetWorkspacePath())
+		processName := backend.ProcessName(sess.ID)
+		if !b.IsAlive(processName) {
 			continue
 		}
 
-		// Capture tmux pane content
-		output, err := cm.captureTmuxPane(tmuxSessionName)
+		// Capture the last 500 lines of worker output
+		output, err := b.CaptureOutput(processName, 500)
 		if err != nil {
 			continue
 		}
@@ -80,23 +122,122 @@ func (cm *CostMonitor) pollAllSessions() {
 	}
 }
 
-// isTmuxSessionRunning checks if a tmux session exists
-func (cm *CostMonitor) isTmuxSessionRunning(sessionName string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName)
-	err := cmd.Run()
-	return err == nil
+// recordHistory appends a snapshot for every session whose usage changed since
+// it was last recorded
+func (cm *CostMonitor) recordHistory(sessions []*session.Session) {
+	if cm.lastSnapshots == nil {
+		cm.lastSnapshots = make(map[string]CostSnapshot)
+	}
+
+	now := time.Now()
+	var snapshots []CostSnapshot
+	for _, sess := range sessions {
+		usage, err := cm.sm.GetTokenUsage(sess.ID)
+		if err != nil || usage.TotalTokens == 0 {
+			continue
+		}
+
+		last, ok := cm.lastSnapshots[sess.ID]
+		if ok && last.TotalTokens == usage.TotalTokens && last.Cost == usage.EstimatedCost {
+			continue
+		}
+
+		snapshot := CostSnapshot{
+			Timestamp:   now,
+			SessionID:   sess.ID,
+			PersonaType: string(sess.PersonaType),
+			TotalTokens: usage.TotalTokens,
+			Cost:        usage.EstimatedCost,
+		}
+		snapshots = append(snapshots, snapshot)
+		cm.lastSnapshots[sess.ID] = snapshot
+	}
+
+	if err := AppendCostSnapshots(cm.sm.GetWorkspacePath(), snapshots); err != nil {
+		fmt.Printf("⚠️  Failed to record cost history: %v\n", err)
+	}
 }
 
-// captureTmuxPane captures the last 500 lines from a tmux pane
-func (cm *CostMonitor) captureTmuxPane(sessionName string) (string, error) {
-	// Capture last 500 lines from the pane
-	cmd := exec.Command("tmux", "capture-pane", "-t", sessionName, "-p", "-S", "-500")
-	output, err := cmd.CombinedOutput()
+// GetCostHistorySummary returns spend per hour and per persona type from the
+// recorded cost history
+func (cm *CostMonitor) GetCostHistorySummary() (string, error) {
+	history, err := LoadCostHistory(cm.sm.GetWorkspacePath())
 	if err != nil {
 		return "", err
 	}
 
-	return string(output), nil
+	var summary strings.Builder
+	summary.WriteString("📈 Team Cost History\n")
+	summary.WriteString("====================\n\n")
+
+	if len(history) == 0 {
+		summary.WriteString("No cost history recorded yet.\n")
+		summary.WriteString("The orchestrator records a snapshot every minute while personas are working.\n")
+		return summary.String(), nil
+	}
+
+	summary.WriteString("Cost per hour:\n")
+	hours, costs := history.HourlyCost()
+	for i, hour := range hours {
+		summary.WriteString(fmt.Sprintf("   %s  %s\n", hour.Local().Format("2006-01-02 15:04"), session.FormatCost(costs[i])))
+	}
+
+	now := time.Now()
+	summary.WriteString("\nCost per persona type:\n")
+	for _, personaType := range history.PersonaTypes() {
+		summary.WriteString(fmt.Sprintf("   %-22s %s\n", personaType, session.FormatCost(history.personaTotalAt(now, personaType))))
+	}
+
+	summary.WriteString("\n====================\n")
+	summary.WriteString(fmt.Sprintf("💵 Total: %s since %s\n", session.FormatCost(history.TotalAt(now)), history[0].Timestamp.Local().Format("2006-01-02 15:04")))
+
+	return summary.String(), nil
+}
+
+// GetForecastSummary projects spend at the burn rate measured over window
+func (cm *CostMonitor) GetForecastSummary(window time.Duration) (string, error) {
+	history, err := LoadCostHistory(cm.sm.GetWorkspacePath())
+	if err != nil {
+		return "", err
+	}
+
+	var summary strings.Builder
+	summary.WriteString("🔮 Team Cost Forecast\n")
+	summary.WriteString("====================\n\n")
+
+	now := time.Now()
+	rate := history.BurnRate(window, now)
+	if rate == 0 {
+		summary.WriteString("Not enough cost history to measure a burn rate yet.\n")
+		return summary.String(), nil
+	}
+
+	total := history.TotalAt(now)
+	summary.WriteString(fmt.Sprintf("🔥 Burn rate: %s (over the last %s)\n\n", FormatBurnRate(rate), formatDuration(window)))
+
+	summary.WriteString("Burn rate per persona type:\n")
+	for _, personaType := range history.PersonaTypes() {
+		if personaRate := history.personaBurnRate(window, now, personaType); personaRate > 0 {
+			summary.WriteString(fmt.Sprintf("   %-22s %s\n", personaType, FormatBurnRate(personaRate)))
+		}
+	}
+
+	summary.WriteString("\nProjected total:\n")
+	for _, horizon := range []time.Duration{time.Hour, 8 * time.Hour, 24 * time.Hour} {
+		summary.WriteString(fmt.Sprintf("   in %-4s %s\n", formatDuration(horizon), session.FormatCost(total+rate*horizon.Hours())))
+	}
+
+	if budget, err := LoadBudget(cm.sm.GetWorkspacePath()); err == nil && budget.USD > 0 {
+		summary.WriteString("\n")
+		if total >= budget.USD {
+			summary.WriteString(fmt.Sprintf("🎯 Team budget of %s is already spent\n", session.FormatCost(budget.USD)))
+		} else {
+			remaining := time.Duration((budget.USD - total) / rate * float64(time.Hour))
+			summary.WriteString(fmt.Sprintf("🎯 Team budget of %s runs out in about %s\n", session.FormatCost(budget.USD), formatDuration(remaining)))
+		}
+	}
+
+	return summary.String(), nil
 }
 
 // GetCurrentCostSummary returns a formatted summary of current costs
@@ -106,7 +247,8 @@ func (cm *CostMonitor) GetCurrentCostSummary() (string, error) {
 		return "", err
 	}
 
-	sessions, err := cm.sm.GetAllSessions()
+	// Finished sessions are listed too, as they count toward the team budget
+	sessions, err := cm.sm.GetSessionHistory()
 	if err != nil {
 		return "", err
 	}
@@ -130,11 +272,22 @@ func (cm *CostMonitor) GetCurrentCostSummary() (string, error) {
 
 		summary.WriteString(fmt.Sprintf("📊 %s (%s)\n", sess.PersonaName, sess.PersonaType))
 		summary.WriteString(fmt.Sprintf("   Session: %s\n", sess.ID))
+		if sess.Status != "active" {
+			summary.WriteString(fmt.Sprintf("   Status: %s\n", sess.Status))
+		}
 		summary.WriteString(fmt.Sprintf("   Model: %s\n", usage.Model))
 		summary.WriteString(fmt.Sprintf("   Input Tokens: %s\n", session.FormatTokens(usage.InputTokens)))
 		summary.WriteString(fmt.Sprintf("   Output Tokens: %s\n", session.FormatTokens(usage.OutputTokens)))
+		if usage.CacheReadTokens > 0 || usage.CacheWriteTokens > 0 {
+			summary.WriteString(fmt.Sprintf("   Cache Tokens: %s read, %s written\n",
+				session.FormatTokens(usage.CacheReadTokens), session.FormatTokens(usage.CacheWriteTokens)))
+		}
 		summary.WriteString(fmt.Sprintf("   Total Tokens: %s\n", session.FormatTokens(usage.TotalTokens)))
-		summary.WriteString(fmt.Sprintf("   Cost: %s\n", session.FormatCost(usage.EstimatedCost)))
+		if usage.Scraped {
+			summary.WriteString(fmt.Sprintf("   Cost: %s (estimated from worker output)\n", session.FormatCost(usage.EstimatedCost)))
+		} else {
+			summary.WriteString(fmt.Sprintf("   Cost: %s\n", session.FormatCost(usage.EstimatedCost)))
+		}
 		summary.WriteString(fmt.Sprintf("   Last Updated: %s\n", usage.LastUpdated.Format("2006-01-02 15:04:05")))
 		summary.WriteString("\n")
 	}
@@ -142,5 +295,19 @@ func (cm *CostMonitor) GetCurrentCostSummary() (string, error) {
 	summary.WriteString("====================\n")
 	summary.WriteString(fmt.Sprintf("💵 Total Team Cost: %s\n", session.FormatCost(totalCost)))
 
+	if history, err := LoadCostHistory(cm.sm.GetWorkspacePath()); err == nil {
+		if rate := history.BurnRate(DefaultBurnRateWindow, time.Now()); rate > 0 {
+			summary.WriteString(fmt.Sprintf("🔥 Burn Rate: %s\n", FormatBurnRate(rate)))
+		}
+	}
+
+	if budget, err := LoadBudget(cm.sm.GetWorkspacePath()); err == nil && budget.IsSet() {
+		var totalTokens int64
+		for _, usage := range usageMap {
+			totalTokens += usage.TotalTokens
+		}
+		summary.WriteString(fmt.Sprintf("🎯 Team Budget: %.0f%% used\n", budget.Fraction(totalCost, totalTokens)*100))
+	}
+
 	return summary.String(), nil
 }
@@ -0,0 +1,374 @@
+package orchestrator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// Cost report output formats
+const (
+	ReportFormatText     = "text"
+	ReportFormatJSON     = "json"
+	ReportFormatCSV      = "csv"
+	ReportFormatMarkdown = "markdown"
+)
+
+// CostUsage is the token usage and cost of a session or a rollup of sessions
+type CostUsage struct {
+	Sessions         int     `json:"sessions"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+func (u *CostUsage) add(other CostUsage) {
+	u.Sessions += other.Sessions
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+	u.TotalTokens += other.TotalTokens
+	u.CostUSD += other.CostUSD
+}
+
+// SessionCost is one persona session's line in a cost report
+type SessionCost struct {
+	Team        string    `json:"team"`
+	SessionID   string    `json:"session_id"`
+	PersonaName string    `json:"persona_name"`
+	PersonaType string    `json:"persona_type"`
+	Model       string    `json:"model"`
+	Status      string    `json:"status"`
+	StartTime   time.Time `json:"start_time"`
+	Estimated   bool      `json:"estimated,omitempty"` // Usage was scraped from worker output
+	CostUsage
+}
+
+// TeamCost is the rollup of one team workspace
+type TeamCost struct {
+	Team        string `json:"team"`
+	Description string `json:"description,omitempty"`
+	CostUsage
+}
+
+// PersonaCost is the rollup of one persona type across the report
+type PersonaCost struct {
+	PersonaType string `json:"persona_type"`
+	CostUsage
+}
+
+// CostReport is a machine-readable breakdown of usage and cost per session,
+// per persona type, per team and in total
+type CostReport struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Since       *time.Time    `json:"since,omitempty"`
+	Until       *time.Time    `json:"until,omitempty"`
+	Sessions    []SessionCost `json:"sessions"`
+	Personas    []PersonaCost `json:"personas"`
+	Teams       []TeamCost    `json:"teams"`
+	Total       CostUsage     `json:"total"`
+}
+
+// BuildCostReport reports on every session of a single team workspace,
+// including completed and archived ones
+func BuildCostReport(sm *session.SessionManager) (*CostReport, error) {
+	report := &CostReport{GeneratedAt: time.Now()}
+	workspacePath := sm.GetWorkspacePath()
+	if resolved, err := filepath.EvalSymlinks(workspacePath); err == nil {
+		workspacePath = resolved
+	}
+	description, _ := session.LoadSessionDescription(workspacePath)
+	if err := report.addTeam(sm, filepath.Base(workspacePath), description, time.Time{}, time.Time{}); err != nil {
+		return nil, err
+	}
+	report.rollup()
+	return report, nil
+}
+
+// BuildHistoricalCostReport reports on all team workspaces under a base
+// workspace, costed with pricing. Only sessions started within [since, until)
+// are included; a zero time leaves that end of the range open.
+func BuildHistoricalCostReport(baseWorkspace string, pricing *session.PricingTable, since, until time.Time) (*CostReport, error) {
+	teams, err := session.ListSessions(baseWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	report := &CostReport{GeneratedAt: time.Now()}
+	if !since.IsZero() {
+		report.Since = &since
+	}
+	if !until.IsZero() {
+		report.Until = &until
+	}
+
+	for _, team := range teams {
+		sm, err := session.OpenSessionManager(team.WorkspacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open session %s: %w", team.ID, err)
+		}
+		sm.SetPricing(pricing)
+		if err := report.addTeam(sm, team.ID, team.Description, since, until); err != nil {
+			return nil, fmt.Errorf("failed to read session %s: %w", team.ID, err)
+		}
+	}
+
+	report.rollup()
+	return report, nil
+}
+
+// addTeam adds the persona sessions of one team workspace
+func (r *CostReport) addTeam(sm *session.SessionManager, team, description string, since, until time.Time) error {
+	sessions, err := sm.GetSessionHistory()
+	if err != nil {
+		return err
+	}
+
+	teamCost := TeamCost{Team: team, Description: description}
+	for _, sess := range sessions {
+		if !since.IsZero() && sess.StartTime.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !sess.StartTime.Before(until) {
+			continue
+		}
+
+		usage, err := sm.GetTokenUsage(sess.ID)
+		if err != nil {
+			continue
+		}
+
+		line := SessionCost{
+			Team:        team,
+			SessionID:   sess.ID,
+			PersonaName: sess.PersonaName,
+			PersonaType: string(sess.PersonaType),
+			Model:       usage.Model,
+			Status:      sess.Status,
+			StartTime:   sess.StartTime,
+			Estimated:   usage.Scraped,
+			CostUsage: CostUsage{
+				Sessions:         1,
+				InputTokens:      usage.InputTokens,
+				OutputTokens:     usage.OutputTokens,
+				CacheReadTokens:  usage.CacheReadTokens,
+				CacheWriteTokens: usage.CacheWriteTokens,
+				TotalTokens:      usage.TotalTokens,
+				CostUSD:          usage.EstimatedCost,
+			},
+		}
+		r.Sessions = append(r.Sessions, line)
+		teamCost.add(line.CostUsage)
+	}
+
+	if teamCost.Sessions > 0 {
+		r.Teams = append(r.Teams, teamCost)
+	}
+	return nil
+}
+
+// rollup fills in the persona type and total rollups from the session lines
+func (r *CostReport) rollup() {
+	sort.SliceStable(r.Sessions, func(i, j int) bool {
+		return r.Sessions[i].StartTime.Before(r.Sessions[j].StartTime)
+	})
+
+	byPersona := make(map[string]*PersonaCost)
+	var personaTypes []string
+	for _, line := range r.Sessions {
+		if byPersona[line.PersonaType] == nil {
+			byPersona[line.PersonaType] = &PersonaCost{PersonaType: line.PersonaType}
+			personaTypes = append(personaTypes, line.PersonaType)
+		}
+		byPersona[line.PersonaType].add(line.CostUsage)
+		r.Total.add(line.CostUsage)
+	}
+
+	sort.Strings(personaTypes)
+	r.Personas = make([]PersonaCost, 0, len(personaTypes))
+	for _, personaType := range personaTypes {
+		r.Personas = append(r.Personas, *byPersona[personaType])
+	}
+	if r.Sessions == nil {
+		r.Sessions = []SessionCost{}
+	}
+	if r.Teams == nil {
+		r.Teams = []TeamCost{}
+	}
+}
+
+// Write renders the report in the given format (text, json, csv or markdown)
+func (r *CostReport) Write(w io.Writer, format string) error {
+	switch format {
+	case ReportFormatText:
+		return r.WriteText(w)
+	case ReportFormatJSON:
+		return r.WriteJSON(w)
+	case ReportFormatCSV:
+		return r.WriteCSV(w)
+	case ReportFormatMarkdown:
+		return r.WriteMarkdown(w)
+	default:
+		return fmt.Errorf("unknown report format '%s' (use text, json, csv or markdown)", format)
+	}
+}
+
+// WriteText renders the report as aligned plain-text tables
+func (r *CostReport) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	usageCells := func(usage CostUsage) string {
+		return session.FormatTokens(usage.TotalTokens) + "\t" + session.FormatCost(usage.CostUSD)
+	}
+
+	fmt.Fprintf(tw, "💰 Cost Report (generated %s", r.GeneratedAt.Format("2006-01-02 15:04:05"))
+	if r.Since != nil {
+		fmt.Fprintf(tw, ", sessions started since %s", r.Since.Format("2006-01-02 15:04"))
+	}
+	if r.Until != nil {
+		fmt.Fprintf(tw, ", until %s", r.Until.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(tw, ")\n")
+
+	fmt.Fprintf(tw, "\nTEAM\tSESSION\tPERSONA\tTYPE\tSTATUS\tTOKENS\tCOST\n")
+	for _, line := range r.Sessions {
+		estimated := ""
+		if line.Estimated {
+			estimated = " (estimated)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s%s\n",
+			line.Team, line.SessionID, line.PersonaName, line.PersonaType, line.Status, usageCells(line.CostUsage), estimated)
+	}
+
+	fmt.Fprintf(tw, "\nPERSONA TYPE\tSESSIONS\tTOKENS\tCOST\n")
+	for _, persona := range r.Personas {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", persona.PersonaType, persona.Sessions, usageCells(persona.CostUsage))
+	}
+
+	fmt.Fprintf(tw, "\nTEAM\tSESSIONS\tTOKENS\tCOST\n")
+	for _, team := range r.Teams {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", team.Team, team.Sessions, usageCells(team.CostUsage))
+	}
+
+	fmt.Fprintf(tw, "\n💵 Total: %s across %d sessions (%s tokens)\n",
+		session.FormatCost(r.Total.CostUSD), r.Total.Sessions, session.FormatTokens(r.Total.TotalTokens))
+	return tw.Flush()
+}
+
+// WriteJSON renders the report as indented JSON
+func (r *CostReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV renders the report as a single CSV table. The level column tells
+// session lines apart from persona, team and total rollups.
+func (r *CostReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"level", "team", "session_id", "persona_name", "persona_type", "model", "status", "start_time",
+		"sessions", "input_tokens", "output_tokens", "cache_read_tokens", "cache_write_tokens", "total_tokens", "cost_usd",
+	})
+
+	row := func(fields []string, usage CostUsage) {
+		cw.Write(append(fields,
+			strconv.Itoa(usage.Sessions),
+			strconv.FormatInt(usage.InputTokens, 10),
+			strconv.FormatInt(usage.OutputTokens, 10),
+			strconv.FormatInt(usage.CacheReadTokens, 10),
+			strconv.FormatInt(usage.CacheWriteTokens, 10),
+			strconv.FormatInt(usage.TotalTokens, 10),
+			strconv.FormatFloat(usage.CostUSD, 'f', 6, 64),
+		))
+	}
+
+	for _, line := range r.Sessions {
+		row([]string{"session", line.Team, line.SessionID, line.PersonaName, line.PersonaType, line.Model, line.Status,
+			line.StartTime.Format(time.RFC3339)}, line.CostUsage)
+	}
+	for _, persona := range r.Personas {
+		row([]string{"persona", "", "", "", persona.PersonaType, "", "", ""}, persona.CostUsage)
+	}
+	for _, team := range r.Teams {
+		row([]string{"team", team.Team, "", "", "", "", "", ""}, team.CostUsage)
+	}
+	row([]string{"total", "", "", "", "", "", "", ""}, r.Total)
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown renders the report as Markdown tables
+func (r *CostReport) WriteMarkdown(w io.Writer) error {
+	fmt.Fprintf(w, "# Cost Report\n\n")
+	fmt.Fprintf(w, "Generated %s", r.GeneratedAt.Format("2006-01-02 15:04:05"))
+	if r.Since != nil {
+		fmt.Fprintf(w, ", sessions started since %s", r.Since.Format("2006-01-02 15:04"))
+	}
+	if r.Until != nil {
+		fmt.Fprintf(w, ", until %s", r.Until.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(w, "\n\n")
+
+	usageColumns := "| Input | Output | Cache Read | Cache Write | Total Tokens | Cost |"
+	usageDivider := "|------:|-------:|-----------:|------------:|-------------:|-----:|"
+	usageCells := func(usage CostUsage) string {
+		return fmt.Sprintf(" %s | %s | %s | %s | %s | %s |",
+			session.FormatTokens(usage.InputTokens),
+			session.FormatTokens(usage.OutputTokens),
+			session.FormatTokens(usage.CacheReadTokens),
+			session.FormatTokens(usage.CacheWriteTokens),
+			session.FormatTokens(usage.TotalTokens),
+			session.FormatCost(usage.CostUSD))
+	}
+
+	fmt.Fprintf(w, "## Sessions\n\n")
+	fmt.Fprintf(w, "| Team | Session | Persona | Type | Model | Status %s\n", usageColumns)
+	fmt.Fprintf(w, "|------|---------|---------|------|-------|--------%s\n", usageDivider)
+	for _, line := range r.Sessions {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |%s\n",
+			line.Team, line.SessionID, line.PersonaName, line.PersonaType, line.Model, line.Status, usageCells(line.CostUsage))
+	}
+
+	fmt.Fprintf(w, "\n## Persona Types\n\n")
+	fmt.Fprintf(w, "| Type | Sessions %s\n", usageColumns)
+	fmt.Fprintf(w, "|------|---------:%s\n", usageDivider)
+	for _, persona := range r.Personas {
+		fmt.Fprintf(w, "| %s | %d |%s\n", persona.PersonaType, persona.Sessions, usageCells(persona.CostUsage))
+	}
+
+	fmt.Fprintf(w, "\n## Teams\n\n")
+	fmt.Fprintf(w, "| Team | Description | Sessions %s\n", usageColumns)
+	fmt.Fprintf(w, "|------|-------------|---------:%s\n", usageDivider)
+	for _, team := range r.Teams {
+		fmt.Fprintf(w, "| %s | %s | %d |%s\n", team.Team, markdownCell(team.Description), team.Sessions, usageCells(team.CostUsage))
+	}
+
+	fmt.Fprintf(w, "\n**Total: %s** across %d sessions (%s tokens)\n",
+		session.FormatCost(r.Total.CostUSD), r.Total.Sessions, session.FormatTokens(r.Total.TotalTokens))
+	return nil
+}
+
+// markdownCell flattens text so it fits in a single table cell
+func markdownCell(text string) string {
+	const maxLen = 60
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	if runes := []rune(text); len(runes) > maxLen {
+		text = string(runes[:maxLen-3]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,72 @@
+package orchestrator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// writeTeam creates a team workspace by hand with one completed persona session
+func writeTeam(t *testing.T, base, team, sessionID string) string {
+	t.Helper()
+	dir := filepath.Join(base, team, sessionID+"-completed")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(base, team, "description.txt"): "Build the thing",
+		filepath.Join(dir, "session.json"):           `{"id":"` + sessionID + `","persona_type":"software-engineer","persona_name":"Turing","status":"completed","start_time":"2026-01-10T10:00:00Z"}`,
+		filepath.Join(dir, session.UsageLogFile):     `{"model":"claude-sonnet-4-5","input_tokens":1000,"output_tokens":500,"cost_usd":0.5}` + "\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(base, team)
+}
+
+func TestHistoricalCostReportLeavesWorkspacesUntouched(t *testing.T) {
+	base := t.TempDir()
+	teamDir := writeTeam(t, base, "team-1", "software-engineer-1")
+
+	report, err := BuildHistoricalCostReport(base, session.DefaultPricing(), time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total.Sessions != 1 || report.Total.CostUSD != 0.5 {
+		t.Errorf("total = %+v, want 1 session costing $0.50", report.Total)
+	}
+	if _, err := os.Stat(filepath.Join(teamDir, "shared")); !os.IsNotExist(err) {
+		t.Errorf("report created %s/shared (err = %v)", teamDir, err)
+	}
+}
+
+func TestCostReportWritesEveryFormat(t *testing.T) {
+	base := t.TempDir()
+	writeTeam(t, base, "team-1", "software-engineer-1")
+	report, err := BuildHistoricalCostReport(base, session.DefaultPricing(), time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{ReportFormatText, ReportFormatJSON, ReportFormatCSV, ReportFormatMarkdown} {
+		var out bytes.Buffer
+		if err := report.Write(&out, format); err != nil {
+			t.Errorf("%s: %v", format, err)
+			continue
+		}
+		if !strings.Contains(out.String(), "software-engineer-1") {
+			t.Errorf("%s report is missing the session:\n%s", format, out.String())
+		}
+	}
+
+	if err := report.Write(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
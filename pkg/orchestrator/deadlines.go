@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// checkDeadlines reminds sessions of unfinished work whose deadline is near or
+// has passed. Each task is nudged once when it comes due and once when it
+// becomes overdue.
+func (o *Orchestrator) checkDeadlines() error {
+	sessions, err := o.sm.GetActiveSessions()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	window := o.cfg.Deadlines.NudgeWindow()
+	for _, sess := range sessions {
+		var lines []string
+
+		if line := o.deadlineNudge(sess.ID+"/request", "Your assignment", sess.Deadline, now, window); line != "" {
+			lines = append(lines, line)
+		}
+
+		if tasks, err := o.sm.LoadTasks(sess.ID); err == nil {
+			for _, task := range tasks.Tasks {
+				if task.Status == session.TaskStatusCompleted {
+					continue
+				}
+				what := fmt.Sprintf("Task %s (%s)", task.ID, task.Description)
+				if task.Priority != "" {
+					what += fmt.Sprintf(", priority %s,", task.Priority)
+				}
+				if line := o.deadlineNudge(sess.ID+"/"+task.ID, what, task.Deadline, now, window); line != "" {
+					lines = append(lines, line)
+				}
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		o.log("\n⏰ Deadline reminder for %s (%s): %d item(s)\n", sess.PersonaName, sess.ID, len(lines))
+		message := fmt.Sprintf("Deadline reminder:\n\n%s\n\nFinish these before other work. If a deadline cannot be met, tell whoever assigned the work.",
+			strings.Join(lines, "\n"))
+		if err := o.sm.WriteInstructions("orchestrator", sess.ID, message); err != nil {
+			o.log("⚠️  Failed to remind %s: %v\n", sess.ID, err)
+		}
+	}
+	return nil
+}
+
+// deadlineNudge returns a reminder line for a deadline that is near or missed
+// and not yet reminded about, or an empty string
+func (o *Orchestrator) deadlineNudge(key, what string, deadline, now time.Time, window time.Duration) string {
+	if deadline.IsZero() || now.Before(deadline.Add(-window)) {
+		return ""
+	}
+
+	level, state := "due", "is due"
+	if now.After(deadline) {
+		level, state = "overdue", "is overdue, it was due"
+	}
+	// Moving the deadline earns a fresh reminder
+	key += fmt.Sprintf(":%d:%s", deadline.Unix(), level)
+	if o.deadlineNudges[key] {
+		return ""
+	}
+	o.deadlineNudges[key] = true
+
+	return fmt.Sprintf("- %s %s %s (%s)", what, state, deadline.Format(session.TaskTimeFormat), session.FormatDue(deadline, now))
+}
@@ -0,0 +1,83 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// loadBlockedTasks restores the blocked tasks recorded by a previous
+// orchestrator, so unblocks that happened while it was down are still announced
+func (o *Orchestrator) loadBlockedTasks() {
+	data, err := os.ReadFile(filepath.Join(o.workspacePath, "orchestrator", "state.json"))
+	if err != nil {
+		return
+	}
+
+	var state OrchestratorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	for _, key := range state.BlockedTasks {
+		o.blockedTasks[key] = true
+	}
+}
+
+// checkTaskDependencies finds tasks whose dependencies have all completed since
+// the last check and tells their owners they can start
+func (o *Orchestrator) checkTaskDependencies() error {
+	graph, err := o.sm.BuildTaskGraph()
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[string]bool)
+	for _, key := range graph.Blocked() {
+		blocked[key] = true
+		if !o.blockedTasks[key] && o.verbose {
+			node := graph.Nodes[key]
+			o.log("⛓️  %s (%s) task %s blocked by %s\n", node.PersonaName, node.SessionID, node.Task.ID,
+				strings.Join(graph.Blockers(key), ", "))
+		}
+	}
+
+	for key := range o.blockedTasks {
+		node := graph.Nodes[key]
+		if blocked[key] || node == nil || node.Done() {
+			continue
+		}
+		if err := o.notifyUnblocked(graph, node); err != nil {
+			o.log("⚠️  Failed to notify %s about unblocked task %s: %v\n", node.SessionID, node.Task.ID, err)
+		}
+	}
+
+	o.blockedTasks = blocked
+	return nil
+}
+
+// notifyUnblocked sends an instruction telling a persona its task can start
+func (o *Orchestrator) notifyUnblocked(graph *session.TaskGraph, node *session.TaskNode) error {
+	// Archived sessions have no worker to notify
+	if _, err := o.sm.GetSession(node.SessionID); err != nil {
+		return nil
+	}
+
+	var done []string
+	for _, dep := range node.DependsOn {
+		if depNode := graph.Nodes[dep]; depNode != nil {
+			done = append(done, fmt.Sprintf("- %s: %s (%s)", dep, depNode.Task.Description, depNode.PersonaName))
+		}
+	}
+
+	o.log("\n🔓 Task %s of %s is unblocked\n", node.Task.ID, node.PersonaName)
+	message := fmt.Sprintf("Task %s (%s) is no longer blocked. Everything it depends on is completed:\n\n%s\n\nYou can start working on it now.",
+		node.Task.ID, node.Task.Description, strings.Join(done, "\n"))
+	if len(node.Unresolved) > 0 {
+		message += fmt.Sprintf("\n\nThese dependencies match no known task and were ignored: %s", strings.Join(node.Unresolved, ", "))
+	}
+	return o.sm.WriteInstructions("orchestrator", node.SessionID, message)
+}
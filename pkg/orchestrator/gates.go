@@ -0,0 +1,276 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// commandOutputLines is how much command output is kept for reports
+const commandOutputLines = 40
+
+// runCommand runs a shell command in dir (the current directory when empty)
+// and returns the tail of its combined output
+func runCommand(dir, command string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %v", timeout)
+		output.WriteString("\n" + err.Error())
+	}
+
+	lines := strings.Split(strings.TrimRight(output.String(), "\n"), "\n")
+	if len(lines) > commandOutputLines {
+		lines = lines[len(lines)-commandOutputLines:]
+	}
+	return strings.Join(lines, "\n"), err
+}
+
+// Kinds of background check
+const (
+	checkGates = "gates" // Completion gate commands
+	checkMerge = "merge" // Merging the session's branch, including the verify command
+)
+
+// backgroundCheck is a gate or merge check running outside the event loop so
+// slow commands do not hold up orchestration
+type backgroundCheck struct {
+	kind string
+	done chan struct{} // Closed when the check has finished
+
+	// Results, set before done is closed
+	passed  []string     // Gate commands that passed
+	command string       // Gate command that failed
+	output  string       // Tail of the failed command's output
+	err     error        // Why the gate command failed
+	merge   *MergeResult // Result of a merge check
+}
+
+// startCheck runs fn in the background as the session's check of the given kind.
+// The event loop is told when it finishes.
+func (o *Orchestrator) startCheck(sessionID, kind string, fn func(*backgroundCheck)) {
+	check := &backgroundCheck{kind: kind, done: make(chan struct{})}
+	o.checks[sessionID] = check
+
+	finished := o.checkFinished
+	go func() {
+		fn(check)
+		close(check.done)
+		// Without room in the channel the next scan picks the result up
+		select {
+		case finished <- sessionID:
+		default:
+		}
+	}()
+}
+
+// finishedCheck returns the session's check of the given kind once it has
+// finished, forgetting it. running reports whether it is still in progress.
+func (o *Orchestrator) finishedCheck(sessionID, kind string) (check *backgroundCheck, running bool) {
+	check, ok := o.checks[sessionID]
+	if !ok || check.kind != kind {
+		return nil, false
+	}
+	select {
+	case <-check.done:
+		delete(o.checks, sessionID)
+		return check, false
+	default:
+		return nil, true
+	}
+}
+
+// checkGates runs a completed session's completion gate and reports whether the
+// session may finish. Gate commands run in the background in the session's
+// worktree, or in the directory the orchestrator was started from; the session
+// is checked again when they finish.
+func (o *Orchestrator) checkGates(sess *session.Session) bool {
+	gate := o.cfg.GateFor(string(sess.PersonaType))
+	if !gate.IsSet() {
+		return true
+	}
+
+	// Commands run once per completion; waiting for a signoff does not rerun them
+	if !o.gatesPassed[sess.ID] && len(gate.Commands) > 0 {
+		check, running := o.finishedCheck(sess.ID, checkGates)
+		if running {
+			return false
+		}
+		if check == nil {
+			o.log("\n🚦 Checking completion gates for %s (%s)\n", sess.PersonaName, sess.ID)
+			dir, commands, timeout := sess.WorktreePath, gate.Commands, gate.CommandTimeout()
+			o.startCheck(sess.ID, checkGates, func(check *backgroundCheck) {
+				for _, command := range commands {
+					output, err := runCommand(dir, command, timeout)
+					if err != nil {
+						check.command, check.output, check.err = command, output, err
+						return
+					}
+					check.passed = append(check.passed, command)
+				}
+			})
+			return false
+		}
+
+		o.log("\n🚦 Completion gates for %s (%s)\n", sess.PersonaName, sess.ID)
+		for _, command := range check.passed {
+			o.log("   ✅ %s\n", command)
+		}
+		if check.err != nil {
+			o.log("   ❌ %s: %v\n", check.command, check.err)
+			report := fmt.Sprintf("## Completion gate failed\n- **Command**: %s\n- **Error**: %v\n", check.command, check.err)
+			if strings.TrimSpace(check.output) != "" {
+				report += fmt.Sprintf("\n```\n%s\n```\n", check.output)
+			}
+			o.sendBack(sess, report, "Fix the problem so the command passes, then mark the task completed again.")
+			return false
+		}
+	}
+	o.gatesPassed[sess.ID] = true
+
+	// QA sessions do not need to sign off on themselves
+	if gate.QASignoff && sess.PersonaType != session.SessionTypeQA {
+		signer := o.findSignoff(sess)
+		if signer == "" {
+			o.requestSignoff(sess)
+			return false
+		}
+		o.log("\n✍️  %s (%s) signed off by %s\n", sess.PersonaName, sess.ID, signer)
+	}
+	return true
+}
+
+// resetGates forgets gate progress for a session whose tasks were reopened. A
+// check still running is abandoned and its result ignored.
+func (o *Orchestrator) resetGates(sessionID string) {
+	delete(o.gatesPassed, sessionID)
+	delete(o.signoffRequested, sessionID)
+	delete(o.checks, sessionID)
+}
+
+// findSignoff returns who signed off the session after its tasks were last
+// reopened, or "" if nobody has. Signoff messages count only from QA sessions;
+// people sign off with "wildwest signoff", which is recorded in session.json.
+func (o *Orchestrator) findSignoff(sess *session.Session) string {
+	if !sess.SignedOffAt.IsZero() && !sess.SignedOffAt.Before(sess.ReopenedAt) {
+		return sess.SignedOffBy
+	}
+
+	messages, err := o.sm.ReadInbox(sess.ID, false)
+	if err != nil {
+		return ""
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		if msg.Type != session.MessageTypeSignoff || msg.To != sess.ID || msg.Timestamp.Before(sess.ReopenedAt) {
+			continue
+		}
+		if msg.FromPersona == session.SessionTypeQA {
+			return msg.From
+		}
+	}
+	return ""
+}
+
+// requestSignoff asks the active QA personas to review a session, or requests a
+// QA persona when there is none. Each completion is only announced once.
+func (o *Orchestrator) requestSignoff(sess *session.Session) {
+	if o.signoffRequested[sess.ID] {
+		return
+	}
+	o.signoffRequested[sess.ID] = true
+
+	absWorkspace, _ := filepath.Abs(o.workspacePath)
+	review := "its deliverables in the project root"
+	if sess.Branch != "" {
+		review = fmt.Sprintf("branch %s (worktree %s)", sess.Branch, sess.WorktreePath)
+	}
+	message := fmt.Sprintf(`%s (%s, %s) has completed its tasks and needs QA sign-off before it can finish.
+
+Review %s. If it is acceptable, sign off with:
+
+wildwest send --workspace %s --from <your session ID> --type signoff %s "Reviewed and approved"
+
+Otherwise send %s instructions describing what needs to be fixed.`,
+		sess.PersonaName, sess.ID, sess.PersonaType, review, absWorkspace, sess.ID, sess.PersonaName)
+
+	active, err := o.sm.GetActiveSessions()
+	if err != nil {
+		o.log("⚠️  Failed to list QA sessions: %v\n", err)
+		return
+	}
+	notified := 0
+	for _, qa := range active {
+		if qa.PersonaType != session.SessionTypeQA {
+			continue
+		}
+		if err := o.sm.WriteInstructions("orchestrator", qa.ID, message); err != nil {
+			o.log("⚠️  Failed to request signoff from %s: %v\n", qa.ID, err)
+			continue
+		}
+		notified++
+	}
+
+	if notified > 0 {
+		o.log("\n✍️  %s (%s) is waiting for QA sign-off (%d QA notified)\n", sess.PersonaName, sess.ID, notified)
+		return
+	}
+
+	// No QA persona yet: request one to do the review
+	requestDir := filepath.Join(o.workspacePath, fmt.Sprintf("%s-request-signoff-%s", session.SessionTypeQA, sess.ID))
+	if err := os.MkdirAll(requestDir, 0755); err == nil {
+		err = session.WriteFileAtomic(filepath.Join(requestDir, "instructions.md"), []byte(message+"\n"), 0644)
+	}
+	if err != nil {
+		o.log("⚠️  Failed to request a QA persona for sign-off: %v\n", err)
+		return
+	}
+	o.log("\n✍️  %s (%s) is waiting for QA sign-off, requested a QA persona\n", sess.PersonaName, sess.ID)
+}
+
+// sendBack reopens a session's last task and appends a report explaining why
+// it could not complete to the agent's instructions.md
+func (o *Orchestrator) sendBack(sess *session.Session, report, hint string) {
+	tasks, err := o.sm.LoadTasks(sess.ID)
+	if err == nil && len(tasks.Tasks) > 0 {
+		task := tasks.Tasks[len(tasks.Tasks)-1]
+		if err := o.sm.SetTaskStatus(sess.ID, task.ID, session.TaskStatusInProgress); err != nil {
+			o.log("   ⚠️  Failed to reopen task %s: %v\n", task.ID, err)
+		} else {
+			report += fmt.Sprintf("\nTask %s (%s) has been reopened.", task.ID, task.Description)
+		}
+	}
+	report += " " + hint
+
+	now := time.Now()
+	sess.ReopenedAt = now
+	if err := o.sm.UpdateSession(sess.ID, func(s *session.Session) { s.ReopenedAt = now }); err != nil {
+		o.log("   ⚠️  Failed to record reopen: %v\n", err)
+	}
+	o.resetGates(sess.ID)
+
+	if err := o.sm.WriteInstructions("orchestrator", sess.ID, report); err != nil {
+		o.log("   ⚠️  Failed to send report to %s: %v\n", sess.ID, err)
+	}
+
+	// A worker that stopped after finishing its tasks is resumed to act on the report
+	if !o.activeSessions[sess.ID] {
+		if err := o.sm.RecordExit(sess.ID, "tasks reopened", "resuming"); err != nil {
+			o.log("   ⚠️  Failed to schedule resume of %s: %v\n", sess.ID, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// waitForCheck waits until the session's background check has finished
+func waitForCheck(t *testing.T, o *Orchestrator, sessionID string) {
+	t.Helper()
+	select {
+	case id := <-o.checkFinished:
+		if id != sessionID {
+			t.Fatalf("check finished for %s, want %s", id, sessionID)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("background check did not finish")
+	}
+}
+
+func TestGateCommandsRunInBackground(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Gates.Default = config.CompletionGate{Commands: []string{"true", "echo broken build; exit 3"}}
+	sess := finishedEngineer(t, o)
+
+	if o.checkGates(sess) {
+		t.Fatal("gates passed before the commands ran")
+	}
+	if o.checkGates(sess) {
+		t.Fatal("gates passed while the commands were running")
+	}
+	waitForCheck(t, o, sess.ID)
+
+	if o.checkGates(sess) {
+		t.Fatal("gates passed although a command failed")
+	}
+	tasks, _ := o.sm.LoadTasks(sess.ID)
+	if tasks.AllCompleted() {
+		t.Error("task not reopened after the gate failed")
+	}
+	inbox, _ := o.sm.ReadInbox(sess.ID, false)
+	if len(inbox) != 1 || !strings.Contains(inbox[0].Content, "broken build") {
+		t.Errorf("gate report = %+v", inbox)
+	}
+}
+
+func TestSignoffOnlyFromQAOrSignoffCommand(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Gates.Default = config.CompletionGate{QASignoff: true}
+	sess := finishedEngineer(t, o)
+	time.Sleep(2 * time.Millisecond)
+	qa, err := o.sm.CreateSession(session.SessionTypeQA, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An agent claiming to be a user cannot sign itself off
+	for _, from := range []string{"user", sess.ID} {
+		if err := o.sm.SendMessage(&session.Message{From: from, To: sess.ID, Type: session.MessageTypeSignoff, Content: "LGTM"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if o.checkGates(sess) {
+		t.Fatal("signoff accepted from a non-QA sender")
+	}
+
+	if err := o.sm.SendMessage(&session.Message{From: qa.ID, To: sess.ID, Type: session.MessageTypeSignoff, Content: "LGTM"}); err != nil {
+		t.Fatal(err)
+	}
+	if !o.checkGates(sess) {
+		t.Error("QA signoff not accepted")
+	}
+
+	// A signoff recorded by "wildwest signoff" counts until the work is reopened
+	other := finishedEngineer(t, o)
+	if err := o.sm.RecordSignoff(other.ID, "user"); err != nil {
+		t.Fatal(err)
+	}
+	other, _ = o.sm.GetSession(other.ID)
+	if o.findSignoff(other) != "user" {
+		t.Error("recorded signoff not accepted")
+	}
+	other.ReopenedAt = time.Now().Add(time.Second)
+	if o.findSignoff(other) != "" {
+		t.Error("signoff from before the work was reopened accepted")
+	}
+}
@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// resolveRequester returns the session that made a spawn request: the one named
+// in its request.yaml or requester file, otherwise the only running session
+// whose ID appears in the request's instructions
+func (o *Orchestrator) resolveRequester(dirName string, meta *session.RequestMeta) string {
+	if meta.Requester != "" {
+		if _, err := o.sm.GetSession(meta.Requester); err == nil {
+			return meta.Requester
+		}
+		o.log("⚠️  %s names unknown requester %s\n", dirName, meta.Requester)
+	}
+
+	data, err := os.ReadFile(filepath.Join(o.workspacePath, dirName, "instructions.md"))
+	if err != nil {
+		return ""
+	}
+	active, err := o.sm.GetActiveSessions()
+	if err != nil {
+		return ""
+	}
+	found := ""
+	for _, sess := range active {
+		if !strings.Contains(string(data), sess.ID) {
+			continue
+		}
+		if found != "" {
+			return ""
+		}
+		found = sess.ID
+	}
+	return found
+}
+
+// reportCompletion tells the session that requested a completed session what
+// was done. When the requester has finished too, the report goes to the
+// nearest running session up the spawn tree.
+func (o *Orchestrator) reportCompletion(sess *session.Session, tasks *session.TaskList) {
+	if sess.ParentSessionID == "" {
+		return
+	}
+	ancestors, err := o.sm.Ancestors(sess)
+	if err != nil {
+		o.log("   ⚠️  Failed to look up requester of %s: %v\n", sess.ID, err)
+		return
+	}
+
+	var to *session.Session
+	for _, a := range ancestors {
+		if a.Running() {
+			to = a
+			break
+		}
+	}
+	if to == nil {
+		return
+	}
+
+	var b strings.Builder
+	if to.ID == sess.ParentSessionID {
+		fmt.Fprintf(&b, "%s (%s, %s) has completed the work you requested.\n", sess.PersonaName, sess.ID, sess.PersonaType)
+	} else {
+		fmt.Fprintf(&b, "%s (%s, %s), requested by %s, has completed its work.\n", sess.PersonaName, sess.ID, sess.PersonaType, sess.ParentSessionID)
+	}
+	b.WriteString("\nCompleted tasks:\n")
+	for _, task := range tasks.Tasks {
+		fmt.Fprintf(&b, "- %s: %s\n", task.ID, task.Description)
+	}
+	if sess.MergeStatus != "" {
+		fmt.Fprintf(&b, "\nBranch %s: %s\n", sess.Branch, sess.MergeDetail)
+	} else if sess.Branch != "" {
+		fmt.Fprintf(&b, "\nThe work is on branch %s.\n", sess.Branch)
+	}
+	absWorkspace, _ := filepath.Abs(o.workspacePath)
+	fmt.Fprintf(&b, "\nIts notes and output files are archived in %s", filepath.Join(absWorkspace, sess.ID+"-completed"))
+
+	err = o.sm.SendMessage(&session.Message{
+		From:    "orchestrator",
+		To:      to.ID,
+		Type:    session.MessageTypeNotification,
+		Subject: fmt.Sprintf("%s completed", sess.PersonaName),
+		Content: b.String(),
+	})
+	if err != nil {
+		o.log("   ⚠️  Failed to report completion to %s: %v\n", to.ID, err)
+		return
+	}
+	o.log("   📨 Reported completion to %s\n", to.ID)
+}
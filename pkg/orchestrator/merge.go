@@ -0,0 +1,247 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/git"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// Merge statuses recorded in session.json
+const (
+	MergeStatusMerged       = "merged"        // Branch merged and verified
+	MergeStatusConflict     = "conflict"      // Branch conflicts with the integration branch
+	MergeStatusVerifyFailed = "verify-failed" // Verify command failed after merging; merge was undone
+	MergeStatusError        = "error"         // Merge could not be attempted
+)
+
+// MergeStatusIcon returns the marker for a merge status (empty: not merged yet)
+func MergeStatusIcon(status string) string {
+	switch status {
+	case MergeStatusMerged:
+		return "✅"
+	case MergeStatusConflict:
+		return "⚔️"
+	case MergeStatusVerifyFailed:
+		return "❌"
+	case MergeStatusError:
+		return "⚠️"
+	default:
+		return "⏳"
+	}
+}
+
+// integrationWorktree is the worktree holding the integration branch
+const integrationWorktree = "integration"
+
+// MergeResult describes one attempt to merge a session's branch
+type MergeResult struct {
+	SessionID string
+	Branch    string
+	Target    string
+	Status    string
+	Commit    string   // Integration commit after a successful merge
+	Conflicts []string // Files that conflicted
+	Verify    string   // Verify command that was run
+	Output    string   // Tail of the verify output, or the error
+}
+
+// Summary returns a one-line description of the result
+func (r *MergeResult) Summary() string {
+	switch r.Status {
+	case MergeStatusMerged:
+		return fmt.Sprintf("merged into %s at %s", r.Target, r.Commit)
+	case MergeStatusConflict:
+		return fmt.Sprintf("conflicts with %s in %d file(s): %s", r.Target, len(r.Conflicts), strings.Join(r.Conflicts, ", "))
+	case MergeStatusVerifyFailed:
+		return fmt.Sprintf("%q failed after merging into %s", r.Verify, r.Target)
+	default:
+		return r.Output
+	}
+}
+
+// Report returns the structured report written to the owning agent
+func (r *MergeResult) Report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Merge report: %s\n", r.Status)
+	fmt.Fprintf(&b, "- **Branch**: %s\n", r.Branch)
+	fmt.Fprintf(&b, "- **Into**: %s\n", r.Target)
+	fmt.Fprintf(&b, "- **Result**: %s\n", r.Summary())
+	if len(r.Conflicts) > 0 {
+		b.WriteString("- **Conflicting files**:\n")
+		for _, file := range r.Conflicts {
+			fmt.Fprintf(&b, "  - %s\n", file)
+		}
+	}
+	if r.Verify != "" && r.Status == MergeStatusVerifyFailed {
+		fmt.Fprintf(&b, "- **Verify command**: %s\n\n```\n%s\n```\n", r.Verify, r.Output)
+	}
+	return b.String()
+}
+
+// Merger integrates session branches into the integration branch. Merges
+// are serialized, so it may be used from several goroutines.
+type Merger struct {
+	sm            *session.SessionManager
+	cfg           config.MergeConfig
+	workspacePath string
+	mu            sync.Mutex // Held while the integration checkout is in use
+}
+
+// NewMerger creates a merger for a workspace
+func NewMerger(sm *session.SessionManager, cfg config.MergeConfig) *Merger {
+	return &Merger{
+		sm:            sm,
+		cfg:           cfg,
+		workspacePath: sm.GetWorkspacePath(),
+	}
+}
+
+// integrationRepo returns a checkout of the integration branch to merge in.
+// The branch gets its own worktree in the workspace, unless the project root
+// has it checked out (git allows a branch in only one worktree). A checkout
+// with uncommitted changes is refused so merging never touches the user's work.
+func (m *Merger) integrationRepo() (*git.Repo, error) {
+	repo, err := git.FindRepo(m.workspacePath)
+	if err != nil {
+		return nil, err
+	}
+
+	target := m.cfg.IntegrationBranch()
+	integration := repo
+	if current, err := repo.CurrentBranch(); err != nil || current != target {
+		absWorkspace, _ := filepath.Abs(m.workspacePath)
+		path := filepath.Join(absWorkspace, worktreesDir, integrationWorktree)
+		if !git.IsWorktree(path) {
+			if err := repo.AddWorktree(path, target, ""); err != nil {
+				return nil, fmt.Errorf("failed to create integration worktree: %w", err)
+			}
+		}
+		integration = &git.Repo{Root: path}
+	}
+
+	dirty, err := integration.HasTrackedChanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s for changes: %w", integration.Root, err)
+	}
+	if dirty {
+		return nil, fmt.Errorf("%s has %s checked out with uncommitted changes; commit or stash them before merging", integration.Root, target)
+	}
+	return integration, nil
+}
+
+// Merge merges a session's branch into the integration branch, runs the verify
+// command and records the outcome in session.json. A failed verification
+// undoes the merge so the integration branch stays green.
+func (m *Merger) Merge(sess *session.Session) *MergeResult {
+	result := &MergeResult{
+		SessionID: sess.ID,
+		Branch:    sess.Branch,
+		Target:    m.cfg.IntegrationBranch(),
+		Verify:    m.cfg.Verify,
+	}
+
+	m.mu.Lock()
+	m.merge(sess, result)
+	m.mu.Unlock()
+
+	if err := m.sm.UpdateSession(sess.ID, func(s *session.Session) {
+		s.MergeStatus = result.Status
+		s.MergeDetail = result.Summary()
+	}); err != nil {
+		result.Output += fmt.Sprintf(" (failed to record merge status: %v)", err)
+	}
+	sess.MergeStatus = result.Status
+	sess.MergeDetail = result.Summary()
+	return result
+}
+
+func (m *Merger) merge(sess *session.Session, result *MergeResult) {
+	fail := func(err error) {
+		result.Status = MergeStatusError
+		result.Output = err.Error()
+	}
+
+	if sess.Branch == "" {
+		fail(fmt.Errorf("session %s has no branch", sess.ID))
+		return
+	}
+
+	repo, err := m.integrationRepo()
+	if err != nil {
+		fail(err)
+		return
+	}
+	before, err := repo.HeadCommit()
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	message := fmt.Sprintf("Merge %s (%s: %s)", sess.Branch, sess.PersonaType, sess.PersonaName)
+	conflicts, err := repo.Merge(sess.Branch, message)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if len(conflicts) > 0 {
+		result.Status = MergeStatusConflict
+		result.Conflicts = conflicts
+		return
+	}
+
+	if m.cfg.Verify != "" {
+		if output, err := runCommand(repo.Root, m.cfg.Verify, m.cfg.Timeout()); err != nil {
+			result.Status = MergeStatusVerifyFailed
+			result.Output = output
+			if resetErr := repo.ResetKeep(before); resetErr != nil {
+				result.Output += fmt.Sprintf("\n(failed to undo merge: %v)", resetErr)
+			}
+			return
+		}
+	}
+
+	result.Status = MergeStatusMerged
+	result.Commit, _ = repo.HeadCommit()
+}
+
+// mergeCompleted merges a completed session's branch in the background and
+// reports whether the merge is done, so the session may finish. The session is
+// checked again when the merge finishes. On a conflict or failed verification
+// the owning agent gets the report and its last task is reopened.
+func (o *Orchestrator) mergeCompleted(sess *session.Session) bool {
+	check, running := o.finishedCheck(sess.ID, checkMerge)
+	if running {
+		return false
+	}
+	if check == nil {
+		o.log("\n🔀 Merging %s (%s) branch %s into %s\n", sess.PersonaName, sess.ID, sess.Branch, o.cfg.Merge.IntegrationBranch())
+		snapshot := *sess
+		o.startCheck(sess.ID, checkMerge, func(check *backgroundCheck) {
+			check.merge = o.merger.Merge(&snapshot)
+		})
+		return false
+	}
+
+	result := check.merge
+	sess.MergeStatus = result.Status
+	sess.MergeDetail = result.Summary()
+
+	switch result.Status {
+	case MergeStatusMerged:
+		o.log("   ✅ %s\n", result.Summary())
+		return true
+	case MergeStatusError:
+		// Nothing the agent can fix; keep the branch for a manual merge
+		o.log("   ⚠️  Merge failed, branch kept for manual merge: %s\n", result.Output)
+		return true
+	}
+
+	o.log("   ❌ Merge %s: %s\n", result.Status, result.Summary())
+	o.sendBack(sess, result.Report(), fmt.Sprintf("Merge %s into your branch in your worktree, fix the problem, commit, and mark the task completed again.", result.Target))
+	return false
+}
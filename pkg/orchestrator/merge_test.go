@@ -0,0 +1,190 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// gitRun runs a git command in dir, failing the test on error
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+	return string(output)
+}
+
+// newMergeFixture creates a repository with a session whose branch adds a file
+func newMergeFixture(t *testing.T) (string, *session.SessionManager, *session.Session) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// The merger commits too, so the identity comes from the environment
+	for _, name := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		t.Setenv(name+"_NAME", "test")
+		t.Setenv(name+"_EMAIL", "test@example.com")
+	}
+
+	root := t.TempDir()
+	gitRun(t, root, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(root, "README"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, root, "add", "README")
+	gitRun(t, root, "commit", "-q", "-m", "initial")
+
+	gitRun(t, root, "checkout", "-q", "-b", "wildwest/eng")
+	if err := os.WriteFile(filepath.Join(root, "feature.go"), []byte("package feature\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, root, "add", "feature.go")
+	gitRun(t, root, "commit", "-q", "-m", "feature")
+	gitRun(t, root, "checkout", "-q", "main")
+
+	sm, err := session.NewSessionManager(filepath.Join(root, ".ww-db", "team"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := sm.CreateSession(session.SessionTypeSoftwareEngineer, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess.Branch = "wildwest/eng"
+	return root, sm, sess
+}
+
+// newMergeOrchestrator creates a quiet orchestrator for the fixture's workspace
+func newMergeOrchestrator(t *testing.T, sm *session.SessionManager, merge config.MergeConfig) *Orchestrator {
+	t.Helper()
+	return &Orchestrator{
+		sm:               sm,
+		cfg:              &config.Config{Merge: merge},
+		workspacePath:    sm.GetWorkspacePath(),
+		tuiMode:          true,
+		backend:          backend.NewProcessBackend(t.TempDir()),
+		activeSessions:   make(map[string]bool),
+		requestWarnings:  make(map[string]bool),
+		gatesPassed:      make(map[string]bool),
+		checks:           make(map[string]*backgroundCheck),
+		checkFinished:    make(chan string, 16),
+		signoffRequested: make(map[string]bool),
+		merger:           NewMerger(sm, merge),
+	}
+}
+
+// completeFixtureSession gives the fixture's session its branch and a completed task
+func completeFixtureSession(t *testing.T, sm *session.SessionManager, sess *session.Session) {
+	t.Helper()
+	if err := sm.UpdateSession(sess.ID, func(s *session.Session) { s.Branch = sess.Branch }); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.AddTask(sess.ID, "Build feature", "system"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.SetTaskStatus(sess.ID, "T1", session.TaskStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeRefusesDirtyProjectRoot(t *testing.T) {
+	root, sm, sess := newMergeFixture(t)
+	gitRun(t, root, "checkout", "-q", "-b", "wildwest/integration")
+	if err := os.WriteFile(filepath.Join(root, "README"), []byte("work in progress\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewMerger(sm, config.MergeConfig{Verify: "false"}).Merge(sess)
+
+	if result.Status != MergeStatusError {
+		t.Errorf("status = %s (%s), want %s", result.Status, result.Summary(), MergeStatusError)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "README")); string(data) != "work in progress\n" {
+		t.Errorf("uncommitted change lost: README = %q", data)
+	}
+}
+
+func TestMergeInIntegrationWorktree(t *testing.T) {
+	root, sm, sess := newMergeFixture(t)
+	worktree := filepath.Join(sm.GetWorkspacePath(), worktreesDir, integrationWorktree)
+
+	// A failed verification undoes the merge
+	result := NewMerger(sm, config.MergeConfig{Verify: "false"}).Merge(sess)
+	if result.Status != MergeStatusVerifyFailed {
+		t.Fatalf("status = %s (%s), want %s", result.Status, result.Summary(), MergeStatusVerifyFailed)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, "feature.go")); !os.IsNotExist(err) {
+		t.Error("merge not undone after the verify command failed")
+	}
+
+	result = NewMerger(sm, config.MergeConfig{Verify: "test -f feature.go"}).Merge(sess)
+	if result.Status != MergeStatusMerged {
+		t.Fatalf("status = %s (%s), want %s", result.Status, result.Summary(), MergeStatusMerged)
+	}
+	if _, err := os.Stat(filepath.Join(root, "feature.go")); !os.IsNotExist(err) {
+		t.Error("merge touched the project root")
+	}
+	if got, _ := sm.GetSession(sess.ID); got.MergeStatus != MergeStatusMerged {
+		t.Errorf("recorded merge status = %q", got.MergeStatus)
+	}
+}
+
+func TestManualMergeLeavesBranchOnCompletion(t *testing.T) {
+	root, sm, sess := newMergeFixture(t)
+	completeFixtureSession(t, sm, sess)
+
+	o := newMergeOrchestrator(t, sm, config.MergeConfig{Manual: true})
+	o.completeIfDone(sess)
+
+	history, err := sm.GetSessionHistory()
+	if err != nil || len(history) != 1 || history[0].Status != "completed" {
+		t.Fatalf("history after completion = %+v, %v", history, err)
+	}
+	if history[0].MergeStatus != "" {
+		t.Errorf("branch merged on completion: %s", history[0].MergeDetail)
+	}
+	if output := gitRun(t, root, "branch", "--list", "wildwest/integration"); output != "" {
+		t.Errorf("integration branch created: %q", output)
+	}
+
+	// "wildwest merge run" can still merge and record it after archiving
+	if result := o.merger.Merge(history[0]); result.Status != MergeStatusMerged {
+		t.Fatalf("manual merge = %s (%s)", result.Status, result.Summary())
+	}
+	if history, _ := sm.GetSessionHistory(); history[0].MergeStatus != MergeStatusMerged {
+		t.Errorf("merge status of the completed session = %q", history[0].MergeStatus)
+	}
+}
+
+func TestCompletionMergesInBackground(t *testing.T) {
+	_, sm, sess := newMergeFixture(t)
+	completeFixtureSession(t, sm, sess)
+	o := newMergeOrchestrator(t, sm, config.MergeConfig{Verify: "sleep 0.2"})
+
+	o.completeIfDone(sess)
+	if got, err := sm.GetSession(sess.ID); err != nil || got.Status == "completed" {
+		t.Fatalf("session finished before its branch was merged: %+v, %v", got, err)
+	}
+
+	select {
+	case <-o.checkFinished:
+	case <-time.After(10 * time.Second):
+		t.Fatal("merge did not finish")
+	}
+	if err := o.handleEvent(WorkspaceEvent{Type: EventCheckFinished, Dir: sess.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := sm.GetSessionHistory()
+	if err != nil || len(history) != 1 || history[0].Status != "completed" || history[0].MergeStatus != MergeStatusMerged {
+		t.Errorf("history after the merge = %+v, %v", history, err)
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
 	"github.com/tarzzz/wildwest/pkg/persona"
 	"github.com/tarzzz/wildwest/pkg/session"
 )
@@ -17,6 +20,8 @@ import (
 type Orchestrator struct {
 	sm              *session.SessionManager
 	personas        *persona.PersonaConfig
+	cfg             *config.Config
+	backend         backend.Backend // Runs persona worker processes
 	activeSessions  map[string]bool // sessionID -> active status
 	workspacePath   string
 	pollInterval    time.Duration
@@ -27,7 +32,21 @@ type Orchestrator struct {
 	completedCount  int
 	failedCount     int
 	tmuxSession     string   // The tmux session this orchestrator is running in
-	spawnedSessions []string // List of all spawned tmux session IDs
+	spawnedSessions []string // List of all spawned worker process names
+	spawningPaused  string          // Why new spawns are paused (empty if allowed)
+	pausedPersonas  map[string]bool // Persona types whose budget pauses new spawns
+	budgetWarnings  map[string]bool // Budget thresholds already reported
+	requestWarnings map[string]bool // Problems with spawn request directories already reported
+	teamBudgetUsed  float64         // Fraction of the team budget spent (0 without a team budget)
+	blockedTasks    map[string]bool // Task keys waiting on unfinished dependencies
+	offeredTasks    map[string]time.Time // When each unassigned board task was last offered to idle personas
+	merger          *Merger         // Merges completed session branches
+	gatesPassed     map[string]bool // Sessions whose gate commands passed for their current completion
+	checks          map[string]*backgroundCheck // Gate commands or merges running for a session
+	checkFinished   chan string                 // Sessions whose background check finished
+	signoffRequested map[string]bool // Sessions whose QA sign-off has been requested
+	spawnQueue      []*QueuedSpawn  // Requests waiting for capacity, in spawn order
+	deadlineNudges  map[string]bool // Deadline reminders already sent
 }
 
 // OrchestratorState represents the orchestrator's state in JSON
@@ -41,7 +60,10 @@ type OrchestratorState struct {
 	CompletedSessions   int       `json:"completed_sessions"`
 	FailedSessions      int       `json:"failed_sessions"`
 	TmuxSession         string    `json:"tmux_session,omitempty"`
-	SpawnedSessions     []string  `json:"spawned_sessions"` // List of all spawned tmux session IDs
+	SpawnedSessions     []string  `json:"spawned_sessions"` // List of all spawned worker process names
+	SpawningPaused      string    `json:"spawning_paused,omitempty"` // Why new spawns are paused
+	BlockedTasks        []string  `json:"blocked_tasks,omitempty"`   // Task keys waiting on dependencies
+	SpawnQueue          []*QueuedSpawn `json:"spawn_queue,omitempty"` // Requests waiting for capacity
 }
 
 // log prints a message unless in TUI mode
@@ -58,8 +80,9 @@ func (o *Orchestrator) logln(args ...interface{}) {
 	}
 }
 
-// NewOrchestrator creates a new orchestrator
-func NewOrchestrator(workspacePath string, verbose bool) (*Orchestrator, error) {
+// NewOrchestrator creates a new orchestrator. configPath is the config file to
+// load; an empty path uses the default locations.
+func NewOrchestrator(workspacePath, configPath string, verbose bool) (*Orchestrator, error) {
 	sm, err := session.NewSessionManager(workspacePath)
 	if err != nil {
 		return nil, err
@@ -70,15 +93,40 @@ func NewOrchestrator(workspacePath string, verbose bool) (*Orchestrator, error)
 		return nil, err
 	}
 
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := backend.New(cfg.Backend, backend.StateDir(workspacePath))
+	if err != nil {
+		return nil, err
+	}
+
+	sm.SetPricing(PricingTable(cfg))
+
 	orch := &Orchestrator{
 		sm:              sm,
 		personas:        personas,
+		cfg:             cfg,
+		backend:         b,
 		activeSessions:  make(map[string]bool),
 		workspacePath:   workspacePath,
 		pollInterval:    5 * time.Second,
 		verbose:         verbose,
 		startTime:       time.Now(),
 		spawnedSessions: make([]string, 0),
+		pausedPersonas:  make(map[string]bool),
+		budgetWarnings:  make(map[string]bool),
+		requestWarnings: make(map[string]bool),
+		blockedTasks:    make(map[string]bool),
+		offeredTasks:    make(map[string]time.Time),
+		merger:          NewMerger(sm, cfg.Merge),
+		gatesPassed:     make(map[string]bool),
+		checks:          make(map[string]*backgroundCheck),
+		checkFinished:   make(chan string, 16),
+		signoffRequested: make(map[string]bool),
+		deadlineNudges:  make(map[string]bool),
 	}
 
 	// Detect tmux session name if running inside tmux
@@ -106,20 +154,46 @@ func NewOrchestrator(workspacePath string, verbose bool) (*Orchestrator, error)
 	return orch, nil
 }
 
+// SetBackend replaces the process backend used to run persona workers
+func (o *Orchestrator) SetBackend(b backend.Backend) {
+	o.backend = b
+}
+
 // Run starts the orchestrator daemon
 func (o *Orchestrator) Run() error {
 	o.logln("🎯 Project Manager Orchestrator Started")
 	o.log("   Workspace: %s\n", o.workspacePath)
+	o.log("   Backend: %s\n", o.backend.Name())
 	o.log("   Poll Interval: %v\n", o.pollInterval)
 	o.logln()
 
+	// Pick up sessions left behind by a previous orchestrator
+	if err := o.reconcile(); err != nil {
+		o.log("⚠️  Error reconciling sessions: %v\n", err)
+	}
+
 	// Start cost monitor in background
 	costMonitor := NewCostMonitor(o.sm)
 	go func() {
 		costMonitor.Start()
 	}()
 
-	ticker := time.NewTicker(o.pollInterval)
+	// React to workspace changes as they happen; polling remains as a fallback
+	// for things that leave no trace on disk, such as a worker being SIGKILLed
+	interval := o.pollInterval
+	var events <-chan WorkspaceEvent
+	var watchErrors <-chan error
+	if watcher, err := NewWatcher(o.workspacePath); err != nil {
+		o.log("⚠️  File watching unavailable (%v), polling every %v\n", err, interval)
+	} else {
+		defer watcher.Close()
+		events = watcher.Events()
+		watchErrors = watcher.Errors()
+		interval = fallbackPollInterval
+		o.log("👀 Watching workspace for changes (fallback poll every %v)\n", interval)
+	}
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	// Initial scan
@@ -133,8 +207,94 @@ func (o *Orchestrator) Run() error {
 			if err := o.scanAndProcess(); err != nil {
 				o.log("⚠️  Error in scan: %v\n", err)
 			}
+
+		case event, ok := <-events:
+			if !ok {
+				// Watcher stopped; fall back to regular polling
+				o.log("⚠️  File watcher stopped, polling every %v\n", o.pollInterval)
+				events, watchErrors = nil, nil
+				ticker.Reset(o.pollInterval)
+				continue
+			}
+			if err := o.handleEvent(event); err != nil {
+				o.log("⚠️  Error handling %s event for %s: %v\n", event.Type, event.Dir, err)
+			}
+
+		case sessionID := <-o.checkFinished:
+			if err := o.handleEvent(WorkspaceEvent{Type: EventCheckFinished, Dir: sessionID}); err != nil {
+				o.log("⚠️  Error finishing checks for %s: %v\n", sessionID, err)
+			}
+
+		case err := <-watchErrors:
+			o.log("⚠️  File watcher error: %v\n", err)
+		}
+	}
+}
+
+// fallbackPollInterval is the full-scan interval while the file watcher is active
+const fallbackPollInterval = 30 * time.Second
+
+// handleEvent runs the part of the scan a workspace event affects
+func (o *Orchestrator) handleEvent(event WorkspaceEvent) error {
+	if o.verbose {
+		o.log("📡 %s: %s\n", event.Type, event.Dir)
+	}
+
+	var err error
+	switch event.Type {
+	case EventRequestCreated:
+		err = o.processSpawnRequests()
+
+	case EventSessionChanged:
+		// New sessions created by "team start" are spawned as soon as they appear
+		if !o.activeSessions[event.Dir] {
+			err = o.processSpawnRequests()
+		}
+
+	case EventTasksChanged:
+		if err := o.sm.SyncBoard(); err != nil {
+			o.log("⚠️  Error syncing task board: %v\n", err)
+		}
+		var sess *session.Session
+		if sess, err = o.sm.GetSession(event.Dir); err == nil {
+			o.completeIfDone(sess)
+			err = o.checkTaskDependencies()
+		}
+
+	case EventWorkerExited:
+		err = o.monitorRunningSessions()
+
+	case EventCheckFinished:
+		var sess *session.Session
+		if sess, err = o.sm.GetSession(event.Dir); err == nil {
+			o.completeIfDone(sess)
+		}
+
+	case EventBoardChanged:
+		err = o.processBoard()
+
+	case EventRequestsDecided:
+		err = o.processSpawnRequests()
+
+	case EventUsageRecorded:
+		if _, err = o.sm.SyncTokenUsage(event.Dir); err == nil {
+			err = o.enforceBudgets()
+		}
+
+	case EventInstructionsAppended:
+		// Workers pick up their own instructions
+		return nil
+	}
+
+	// A session that finished or exited may have freed capacity for queued requests
+	if len(o.spawnQueue) > 0 && (event.Type == EventTasksChanged || event.Type == EventWorkerExited || event.Type == EventCheckFinished) {
+		if err := o.processSpawnRequests(); err != nil {
+			o.log("⚠️  Error processing spawn queue: %v\n", err)
 		}
 	}
+
+	o.saveState()
+	return err
 }
 
 // RunTUI starts the orchestrator with interactive TUI
@@ -146,6 +306,11 @@ func (o *Orchestrator) RunTUI() error {
 
 // scanAndProcess scans for requests and manages sessions
 func (o *Orchestrator) scanAndProcess() error {
+	// 0. Apply budgets before anything new is spawned
+	if err := o.enforceBudgets(); err != nil {
+		o.log("⚠️  Error checking budgets: %v\n", err)
+	}
+
 	// 1. Check for new spawn requests
 	if err := o.processSpawnRequests(); err != nil {
 		return err
@@ -161,7 +326,27 @@ func (o *Orchestrator) scanAndProcess() error {
 		return err
 	}
 
-	// 4. Update orchestrator state
+	// 4. Restart crashed sessions whose backoff has elapsed and resume reopened ones
+	if err := o.processRestarts(); err != nil {
+		return err
+	}
+
+	// 5. Sync the task board with persona task lists and offer open tasks
+	if err := o.processBoard(); err != nil {
+		o.log("⚠️  Error processing task board: %v\n", err)
+	}
+
+	// 6. Tell personas when the tasks they were waiting on are done
+	if err := o.checkTaskDependencies(); err != nil {
+		o.log("⚠️  Error checking task dependencies: %v\n", err)
+	}
+
+	// 7. Remind owners of deadlines that are near or missed
+	if err := o.checkDeadlines(); err != nil {
+		o.log("⚠️  Error checking deadlines: %v\n", err)
+	}
+
+	// 8. Update orchestrator state
 	o.saveState()
 
 	return nil
@@ -174,6 +359,15 @@ func (o *Orchestrator) processSpawnRequests() error {
 		return err
 	}
 
+	// Forget queued requests whose directory was removed
+	if o.cfg.Approval.Enabled {
+		o.pruneRequests()
+	}
+
+	// Requests waiting for capacity go first, in queue order
+	o.processSpawnQueue()
+
+	var requests []string
 	for _, entry := range entries {
 		if !entry.IsDir() || entry.Name() == "shared" {
 			continue
@@ -182,9 +376,9 @@ func (o *Orchestrator) processSpawnRequests() error {
 		dirName := entry.Name()
 
 		// Check if it's a request directory
-		if strings.Contains(dirName, "-request-") {
-			if err := o.handleSpawnRequest(dirName); err != nil {
-				o.log("⚠️  Failed to handle spawn request %s: %v\n", dirName, err)
+		if strings.Contains(dirName, persona.RequestInfix) {
+			if o.queuePosition(dirName) == 0 && o.requestReady(dirName) {
+				requests = append(requests, dirName)
 			}
 			continue
 		}
@@ -195,20 +389,16 @@ func (o *Orchestrator) processSpawnRequests() error {
 		}
 
 		// Check for initial sessions that need spawning (not yet running)
-		if strings.HasPrefix(dirName, "engineering-manager-") ||
-			strings.HasPrefix(dirName, "solutions-architect-") ||
-			strings.HasPrefix(dirName, "software-engineer-") ||
-			strings.HasPrefix(dirName, "intern-") {
+		if _, ok := o.personas.SessionType(dirName); ok {
 
 			// Skip if already running
 			if o.activeSessions[dirName] {
 				continue
 			}
 
-			// Check if session exists and is active
-			sessionFile := filepath.Join(o.workspacePath, dirName, "session.json")
-			if _, err := os.Stat(sessionFile); err == nil {
-				// Session exists, spawn it
+			// Check if session exists and is active; stopped sessions are
+			// left to the restart policy
+			if sess, err := o.sm.GetSession(dirName); err == nil && sess.Status == "active" {
 				if err := o.handleSpawnRequest(dirName); err != nil {
 					o.log("⚠️  Failed to spawn session %s: %v\n", dirName, err)
 				}
@@ -216,45 +406,69 @@ func (o *Orchestrator) processSpawnRequests() error {
 		}
 	}
 
+	// New requests are spawned by the priority in their request.yaml
+	o.sortRequestsByPriority(requests)
+	for _, dirName := range requests {
+		if err := o.handleSpawnRequest(dirName); err != nil {
+			o.log("⚠️  Failed to handle spawn request %s: %v\n", dirName, err)
+		}
+	}
+
 	return nil
 }
 
+// requestGracePeriod is how long a request directory may exist without
+// instructions.md before it is spawned anyway
+const requestGracePeriod = 30 * time.Second
+
+// requestReady reports whether a request directory has its instructions written,
+// so a spawn never races the requester creating the directory
+func (o *Orchestrator) requestReady(dirName string) bool {
+	dir := filepath.Join(o.workspacePath, dirName)
+	if _, err := os.Stat(filepath.Join(dir, "instructions.md")); err == nil {
+		return true
+	}
+	info, err := os.Stat(dir)
+	return err == nil && time.Since(info.ModTime()) > requestGracePeriod
+}
+
+// rejectUnknownRequest removes a request for a persona type that is not
+// configured and tells the requester which types exist
+func (o *Orchestrator) rejectUnknownRequest(dirName string) {
+	requested := dirName[:strings.Index(dirName, persona.RequestInfix)]
+	o.log("\n🚫 Rejecting %s: no '%s' persona is configured\n", dirName, requested)
+
+	if requester := o.resolveRequester(dirName, o.loadRequestMeta(dirName)); requester != "" {
+		message := fmt.Sprintf("Your spawn request %s was rejected: there is no '%s' persona.\n\nAvailable persona types: %s\n\nCreate the request again as {persona-type}%s{name}.",
+			dirName, requested, strings.Join(o.personas.Types(), ", "), persona.RequestInfix)
+		if err := o.sm.WriteInstructions("orchestrator", requester, message); err != nil {
+			o.log("⚠️  Failed to notify %s: %v\n", requester, err)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(o.workspacePath, dirName)); err != nil {
+		o.log("⚠️  Failed to remove request directory: %v\n", err)
+	}
+}
+
 // handleSpawnRequest processes a spawn request
 func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 	requestPath := filepath.Join(o.workspacePath, dirName)
 
-	// Determine persona type
+	// Determine persona type from the configured persona keys: request
+	// directories are dynamic spawns, session directories initial ones
 	var personaType session.SessionType
 	var isInitialSpawn bool
 
-	// Handle request directories (dynamic spawns)
-	if strings.HasPrefix(dirName, "solutions-architect-request-") {
-		personaType = session.SessionTypeSolutionsArchitect
-	} else if strings.HasPrefix(dirName, "software-engineer-request-") {
-		personaType = session.SessionTypeSoftwareEngineer
-	} else if strings.HasPrefix(dirName, "qa-request-") {
-		personaType = session.SessionTypeQA
-	} else if strings.HasPrefix(dirName, "intern-request-") {
-		personaType = session.SessionTypeIntern
-	} else if strings.HasPrefix(dirName, "engineering-manager-") {
-		// Initial manager spawn
-		personaType = session.SessionTypeEngineeringManager
-		isInitialSpawn = true
-	} else if strings.HasPrefix(dirName, "solutions-architect-") {
-		// Initial architect spawn
-		personaType = session.SessionTypeSolutionsArchitect
-		isInitialSpawn = true
-	} else if strings.HasPrefix(dirName, "software-engineer-") {
-		// Initial engineer spawn
-		personaType = session.SessionTypeSoftwareEngineer
-		isInitialSpawn = true
-	} else if strings.HasPrefix(dirName, "qa-") {
-		// Initial QA spawn
-		personaType = session.SessionTypeQA
-		isInitialSpawn = true
-	} else if strings.HasPrefix(dirName, "intern-") {
-		// Initial intern spawn
-		personaType = session.SessionTypeIntern
+	if strings.Contains(dirName, persona.RequestInfix) {
+		key, ok := o.personas.RequestType(dirName)
+		if !ok {
+			o.rejectUnknownRequest(dirName)
+			return nil
+		}
+		personaType = session.SessionType(key)
+	} else if key, ok := o.personas.SessionType(dirName); ok {
+		personaType = session.SessionType(key)
 		isInitialSpawn = true
 	} else {
 		return fmt.Errorf("unknown request type: %s", dirName)
@@ -265,6 +479,29 @@ func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 		return nil
 	}
 
+	// Leave the request in place while budgets pause spawning
+	if !o.spawnAllowed(personaType) {
+		if key := "deferred:" + dirName; !o.requestWarnings[key] {
+			o.requestWarnings[key] = true
+			o.log("⏸️  Deferring %s: spawning paused by budget\n", dirName)
+		}
+		return nil
+	}
+
+	// Requests wait for a human decision in approval mode
+	if !isInitialSpawn && !o.requestApproved(dirName, personaType) {
+		return nil
+	}
+
+	// Requests beyond the concurrency limits wait in the spawn queue
+	if !isInitialSpawn {
+		if reason, ok := o.capacityFor(personaType); !ok {
+			o.enqueueSpawn(dirName, personaType, reason)
+			return nil
+		}
+		o.dequeueSpawn(dirName)
+	}
+
 	// Mark request directory as active immediately to prevent duplicate spawns
 	// This is critical because for request directories, a new session ID will be generated
 	// and we need to track BOTH the request directory name AND the new session ID
@@ -286,17 +523,22 @@ func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 			return fmt.Errorf("session not found: %s", dirName)
 		}
 	} else {
+		// Read request.yaml and the requester before the request directory is removed
+		meta := o.loadRequestMeta(dirName)
+		requester := o.resolveRequester(dirName, meta)
+
 		// Create new session for request (name will be auto-generated)
-		sess, err = o.sm.CreateSession(personaType, "", "main")
+		sess, err = o.sm.CreateSession(personaType, "", "main", requester)
 		if err != nil {
 			return err
 		}
+		o.applyRequestMeta(sess, meta)
 
-		// Move/copy instructions from request directory to new session
+		// Deliver the request's instructions to the new session through the message store
 		requestInstructions := filepath.Join(requestPath, "instructions.md")
 		if data, err := os.ReadFile(requestInstructions); err == nil {
-			sessionInstructions := filepath.Join(o.workspacePath, sess.ID, "instructions.md")
-			if err := os.WriteFile(sessionInstructions, data, 0644); err != nil {
+			message := strings.TrimRight(string(data), "\n") + requestMetaNote(sess)
+			if err := o.sm.WriteInstructions("orchestrator", sess.ID, message); err != nil {
 				o.log("⚠️  Failed to copy instructions: %v\n", err)
 			}
 		}
@@ -307,16 +549,40 @@ func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 		}
 	}
 
-	o.log("\n🚀 Spawning %s: %s\n", personaType, sess.PersonaName)
+	if sess.ParentSessionID != "" {
+		o.log("\n🚀 Spawning %s: %s (requested by %s)\n", personaType, sess.PersonaName, sess.ParentSessionID)
+	} else {
+		o.log("\n🚀 Spawning %s: %s\n", personaType, sess.PersonaName)
+	}
+
+	if err := o.spawnSession(sess, false); err != nil {
+		return err
+	}
+	o.totalSpawned++
 
+	return nil
+}
+
+// spawnSession writes a session's persona instructions and wrapper script and
+// starts its worker process. Existing tasks.md, instructions.md and tracker.json
+// are left untouched so a restarted worker resumes where it left off.
+func (o *Orchestrator) spawnSession(sess *session.Session, resumed bool) error {
 	// Get persona definition
-	p, err := o.personas.GetPersona(string(personaType))
+	p, err := o.personas.GetPersona(string(sess.PersonaType))
 	if err != nil {
 		return err
 	}
 
+	// Isolated sessions run in their own git worktree
+	if err := o.prepareWorktree(sess); err != nil {
+		return err
+	}
+
 	// Create enhanced instructions
-	instructions := o.generateInstructions(p, sess)
+	instructions, err := o.generateInstructions(p, sess)
+	if err != nil {
+		return err
+	}
 
 	// Write instructions to a temporary file for Claude to read
 	instructionsFile := filepath.Join(o.workspacePath, sess.ID, "persona-instructions.md")
@@ -324,37 +590,63 @@ func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 		return fmt.Errorf("failed to write instructions: %w", err)
 	}
 
-	// Create tmux session name (sanitized)
-	tmuxSessionName := fmt.Sprintf("claude-%s", sess.ID)
+	// Worker process name (sanitized)
+	processName := backend.ProcessName(sess.ID)
 
 	// Get absolute paths for persona files
 	absWorkspace, _ := filepath.Abs(o.workspacePath)
 	absSessionDir := filepath.Join(absWorkspace, sess.ID)
 
 	// Create wrapper script that keeps Claude alive and monitors for new instructions
-	wrapperScript := o.createWrapperScript(sess.ID, absSessionDir)
+	workDir := absSessionDir
+	if sess.WorktreePath != "" {
+		workDir = sess.WorktreePath
+	}
+	// A model named in the spawn request overrides the persona's model
+	model := p.Model
+	if sess.RequestedModel != "" {
+		model = sess.RequestedModel
+	}
+	wrapperScript := o.createWrapperScript(sess.ID, absSessionDir, workDir, model, resumed)
 	wrapperPath := filepath.Join(absSessionDir, "worker.sh")
 	if err := os.WriteFile(wrapperPath, []byte(wrapperScript), 0755); err != nil {
 		return fmt.Errorf("failed to create wrapper script: %w", err)
 	}
 
-	// Create tmux session and run the wrapper script
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", tmuxSessionName, "bash", wrapperPath)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to start tmux session: %w (output: %s)", err, string(output))
+	// A worker that is still alive is adopted rather than spawned twice
+	if o.isSessionRunning(sess.ID) {
+		o.log("   🔗 Worker already running, re-adopting %s\n", processName)
+		o.trackSpawned(processName)
+		o.activeSessions[sess.ID] = true
+		return nil
+	}
+
+	// Clear the exit status left by a previous worker
+	os.Remove(filepath.Join(absSessionDir, exitStatusFile))
+
+	// Start the worker process running the wrapper script
+	if err := o.backend.Spawn(processName, wrapperPath, absSessionDir); err != nil {
+		return err
 	}
 
 	// Track this spawned session
-	o.spawnedSessions = append(o.spawnedSessions, tmuxSessionName)
+	o.trackSpawned(processName)
 
-	// Update session.json with tmux info
-	if err := o.sm.UpdateTmuxSession(sess.ID, tmuxSessionName, true); err != nil {
-		o.log("⚠️  Failed to update tmux session info: %v\n", err)
+	// Update session.json with worker process info
+	attachCommand := o.backend.AttachCommand(processName)
+	if err := o.sm.UpdateWorkerProcess(sess.ID, o.backend.Name(), processName, attachCommand, true); err != nil {
+		o.log("⚠️  Failed to update worker process info: %v\n", err)
+	}
+
+	// Record the persona's model so costs are priced correctly before usage is logged
+	if model != "" {
+		if err := o.sm.UpdateSession(sess.ID, func(s *session.Session) { s.Model = model }); err != nil {
+			o.log("⚠️  Failed to record model: %v\n", err)
+		}
 	}
 
 	// Write attach command file to persona directory
-	attachCmd := fmt.Sprintf("#!/bin/bash\nclear\ntmux attach -t %s\n", tmuxSessionName)
+	attachCmd := fmt.Sprintf("#!/bin/bash\nclear\n%s\n", attachCommand)
 	attachFile := filepath.Join(absSessionDir, "attach.sh")
 	if err := os.WriteFile(attachFile, []byte(attachCmd), 0755); err != nil {
 		o.log("⚠️  Failed to write attach command: %v\n", err)
@@ -362,90 +654,178 @@ func (o *Orchestrator) handleSpawnRequest(dirName string) error {
 
 	// Mark session as active
 	o.activeSessions[sess.ID] = true
-	o.totalSpawned++
 
-	o.log("   ✅ Session: %s (tmux: %s)\n", sess.ID, tmuxSessionName)
-	o.log("   📎 Attach with: tmux attach -t %s\n", tmuxSessionName)
+	o.log("   ✅ Session: %s (%s: %s)\n", sess.ID, o.backend.Name(), processName)
+	o.log("   📎 Attach with: %s\n", attachCommand)
 	o.log("   📄 Or run: %s/attach.sh\n", absSessionDir)
 
 	return nil
 }
 
-// isTmuxSessionRunning checks if a tmux session exists
-func (o *Orchestrator) isTmuxSessionRunning(sessionID string) bool {
-	tmuxSessionName := fmt.Sprintf("claude-%s", sessionID)
-	cmd := exec.Command("tmux", "has-session", "-t", tmuxSessionName)
-	err := cmd.Run()
-	return err == nil
+// trackSpawned records a worker process name once
+func (o *Orchestrator) trackSpawned(processName string) {
+	for _, name := range o.spawnedSessions {
+		if name == processName {
+			return
+		}
+	}
+	o.spawnedSessions = append(o.spawnedSessions, processName)
 }
 
-// processCompletedSessions checks for completed sessions and cleans up
-func (o *Orchestrator) processCompletedSessions() error {
-	sessions, err := o.sm.GetAllSessions()
+// backendFor returns the backend running a session's worker. Sessions adopted from
+// a previous orchestrator may use a different backend than this one.
+func (o *Orchestrator) backendFor(sessionID string) backend.Backend {
+	sess, err := o.sm.GetSession(sessionID)
+	if err != nil || sess.Backend == "" || sess.Backend == o.backend.Name() {
+		return o.backend
+	}
+	return backend.ForSession(sess.Backend, o.workspacePath)
+}
+
+// isSessionRunning checks if a session's worker process exists
+func (o *Orchestrator) isSessionRunning(sessionID string) bool {
+	return o.backendFor(sessionID).IsAlive(backend.ProcessName(sessionID))
+}
+
+// reconcile rebuilds in-memory state from the workspace after an orchestrator
+// restart: live workers are re-adopted, workers that died while no orchestrator
+// was running are handed to the restart policy, and counters are recomputed
+// from session.json so only genuinely missing sessions get spawned.
+func (o *Orchestrator) reconcile() error {
+	history, err := o.sm.GetSessionHistory()
 	if err != nil {
 		return err
 	}
 
-	for _, sess := range sessions {
-		// Skip if still running
-		if o.activeSessions[sess.ID] {
+	o.totalSpawned, o.completedCount, o.failedCount = 0, 0, 0
+	adopted, lost := 0, 0
+	o.loadBlockedTasks()
+
+	for _, sess := range history {
+		if sess.TmuxSpawned {
+			o.totalSpawned += 1 + sess.RestartCount
+		}
+
+		switch sess.Status {
+		case "completed", "archived":
+			o.completedCount++
 			continue
+		case "failed", "stopped":
+			o.failedCount++
 		}
 
-		// Skip if already marked completed
-		if sess.Status == "completed" || sess.Status == "archived" {
+		// Archived directories no longer hold a running session
+		if _, err := os.Stat(filepath.Join(o.workspacePath, sess.ID, "session.json")); err != nil {
 			continue
 		}
 
-		// Check if all tasks are completed
-		tasks, err := o.sm.ReadTasks(sess.ID)
-		if err != nil {
+		processName := backend.ProcessName(sess.ID)
+		if o.isSessionRunning(sess.ID) {
+			if sess.Status != "active" {
+				if sess.Status == "failed" || sess.Status == "stopped" {
+					o.failedCount--
+				}
+				o.sm.UpdateSessionStatus(sess.ID, "active")
+			}
+			o.activeSessions[sess.ID] = true
+			o.trackSpawned(processName)
+			o.log("🔗 Re-adopted %s (%s)\n", sess.PersonaName, processName)
+			adopted++
 			continue
 		}
 
-		if o.areAllTasksCompleted(tasks) {
-			o.log("\n🎉 All tasks completed for %s (%s)\n", sess.PersonaName, sess.ID)
+		// Spawned earlier but gone now: let monitorRunningSessions record the
+		// exit and apply the restart policy instead of blindly respawning
+		if sess.Status == "active" && sess.TmuxSpawned {
+			o.activeSessions[sess.ID] = true
+			lost++
+		}
+	}
 
-			// Terminate tmux session if still running
-			if o.isTmuxSessionRunning(sess.ID) {
-				tmuxSessionName := fmt.Sprintf("claude-%s", sess.ID)
-				exec.Command("tmux", "kill-session", "-t", tmuxSessionName).Run()
-				delete(o.activeSessions, sess.ID)
-			}
+	if adopted > 0 || lost > 0 {
+		o.log("♻️  Reconciled workspace: %d running, %d exited while orchestrator was down\n", adopted, lost)
+	}
 
-			// Mark as completed
-			o.sm.UpdateSessionStatus(sess.ID, "completed")
-			o.completedCount++
+	return nil
+}
+
+// processCompletedSessions checks for completed sessions and cleans up
+func (o *Orchestrator) processCompletedSessions() error {
+	sessions, err := o.sm.GetAllSessions()
+	if err != nil {
+		return err
+	}
 
-			// Archive the directory
-			o.archiveSession(sess.ID)
+	for _, sess := range sessions {
+		// Skip if still running
+		if o.activeSessions[sess.ID] {
+			continue
 		}
+		o.completeIfDone(sess)
 	}
 
 	return nil
 }
 
-// areAllTasksCompleted checks if all tasks in tasks.md are completed
-func (o *Orchestrator) areAllTasksCompleted(tasks string) bool {
-	if !strings.Contains(tasks, "## Task:") {
-		return false // No tasks defined
+// completeIfDone archives a session once all its tasks are completed. Running
+// workers are left alone: they stop on their own between turns once every task
+// is completed, and the session is finished when their exit is noticed.
+func (o *Orchestrator) completeIfDone(sess *session.Session) {
+	// Skip if still running
+	if o.activeSessions[sess.ID] {
+		return
 	}
 
-	lines := strings.Split(tasks, "\n")
-	hasIncompleteTasks := false
+	// Skip if already marked completed
+	if sess.Status == "completed" || sess.Status == "archived" {
+		return
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "**Status**:") {
-			status := strings.TrimSpace(strings.Split(line, ":")[1])
-			if status != "completed" {
-				hasIncompleteTasks = true
-				break
-			}
-		}
+	// Check if all tasks are completed
+	tasks, err := o.sm.LoadTasks(sess.ID)
+	if err != nil || !tasks.AllCompleted() {
+		o.resetGates(sess.ID)
+		return
+	}
+
+	// Personas that claim board tasks stay on while unassigned tasks are waiting
+	if o.assignBoardTask(sess) {
+		return
+	}
+
+	// Completion gates must pass before the session may finish
+	if !o.checkGates(sess) {
+		return
+	}
+
+	// Integrate the session's branch; a failed merge sends the work back to the agent
+	if sess.Branch != "" && !o.cfg.Merge.Manual && !o.mergeCompleted(sess) {
+		return
 	}
 
-	return !hasIncompleteTasks
+	o.log("\n🎉 All tasks completed for %s (%s)\n", sess.PersonaName, sess.ID)
+	if sess.Branch != "" && o.cfg.Merge.Manual {
+		o.log("   🔀 Branch %s kept for \"wildwest merge run\"\n", sess.Branch)
+	}
+
+	// Terminate worker process if still running
+	if o.isSessionRunning(sess.ID) {
+		o.backendFor(sess.ID).Kill(backend.ProcessName(sess.ID))
+	}
+	delete(o.activeSessions, sess.ID)
+
+	// Mark as completed
+	o.sm.UpdateSessionStatus(sess.ID, "completed")
+	o.completedCount++
+
+	// Keep the branch for review, drop the worktree
+	o.releaseWorktree(sess)
+
+	// Tell whoever asked for this session that the work is done
+	o.reportCompletion(sess, tasks)
+
+	// Archive the directory
+	o.archiveSession(sess.ID)
 }
 
 // archiveSession archives a completed session
@@ -461,59 +841,174 @@ func (o *Orchestrator) archiveSession(sessionID string) error {
 	return nil
 }
 
+// exitStatusFile is written by the worker script with its exit code
+const exitStatusFile = "exit_status"
+
 // monitorRunningSessions checks health of running sessions
 func (o *Orchestrator) monitorRunningSessions() error {
-	// Check if tmux sessions are still alive
+	// Check if worker processes are still alive
 	for sessionID := range o.activeSessions {
-		if !o.isTmuxSessionRunning(sessionID) {
-			// Get session info to show which one stopped
-			sessions, _ := o.sm.GetAllSessions()
-			var personaName string
-			for _, s := range sessions {
-				if s.ID == sessionID {
-					personaName = s.PersonaName
-					break
-				}
-			}
+		// Request directories are tracked only to prevent duplicate spawns
+		if strings.Contains(sessionID, persona.RequestInfix) {
+			continue
+		}
+
+		if o.isSessionRunning(sessionID) {
+			continue
+		}
+
+		delete(o.activeSessions, sessionID)
+
+		// Get session info to show which one stopped
+		sess, err := o.sm.GetSession(sessionID)
+		if err != nil {
+			o.log("\n⚠️  Session stopped: %s\n", sessionID)
+			o.failedCount++
+			continue
+		}
+		o.log("\n⚠️  Session stopped: %s (%s)\n", sess.PersonaName, sessionID)
+
+		reason, failed := o.workerExitReason(sessionID)
+		o.log("   💥 %s\n", reason)
+
+		// A worker stops by itself once all its tasks are completed; finish the
+		// session (gates, merge, archive) now that it is between turns
+		tasks, err := o.sm.LoadTasks(sessionID)
+		if err == nil && tasks.AllCompleted() {
+			o.log("   📋 All tasks were completed\n")
+			o.sm.RecordExit(sessionID, reason, "stopped")
+			sess.Status = "stopped"
+			o.completeIfDone(sess)
+			continue
+		}
+
+		policy := o.cfg.RestartPolicyFor(string(sess.PersonaType))
+		if policy.AllowsRestart(sess.RestartCount, failed) {
+			delay := policy.Delay(sess.RestartCount)
+			o.log("   🔁 Restarting in %v (restart %d, policy %s)\n", delay, sess.RestartCount+1, policy.Policy)
+			o.sm.RecordExit(sessionID, reason, "restarting")
+			continue
+		}
+
+		status := "stopped"
+		if sess.RestartCount > 0 {
+			reason = fmt.Sprintf("%s (gave up after %d restarts)", reason, sess.RestartCount)
+			status = "failed"
+		}
+		o.log("   📋 Session did not complete all tasks\n")
+		o.sm.RecordExit(sessionID, reason, status)
+		o.failedCount++
+	}
+
+	return nil
+}
+
+// workerExitReason describes why a session's worker exited and whether it failed
+func (o *Orchestrator) workerExitReason(sessionID string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(o.workspacePath, sessionID, exitStatusFile))
+	if err != nil {
+		return "worker process disappeared (killed or crashed)", true
+	}
+
+	code := strings.TrimSpace(string(data))
+	if code == "0" {
+		return "worker exited normally", false
+	}
+	return fmt.Sprintf("worker exited with code %s", code), true
+}
+
+// processRestarts respawns sessions waiting for a restart once their backoff
+// elapses, and sessions resumed to act on new work (a failed gate, an assigned
+// board task) right away. Resumes do not count against the restart policy.
+func (o *Orchestrator) processRestarts() error {
+	sessions, err := o.sm.GetAllSessions()
+	if err != nil {
+		return err
+	}
 
-			if personaName != "" {
-				o.log("\n⚠️  Session stopped: %s (%s)\n", personaName, sessionID)
-			} else {
-				o.log("\n⚠️  Session stopped: %s\n", sessionID)
+	for _, sess := range sessions {
+		resume := sess.Status == "resuming"
+		if (sess.Status != "restarting" && !resume) || o.activeSessions[sess.ID] {
+			continue
+		}
+
+		if !resume {
+			policy := o.cfg.RestartPolicyFor(string(sess.PersonaType))
+			if time.Now().Before(sess.LastExitAt.Add(policy.Delay(sess.RestartCount))) {
+				continue
 			}
+		}
+		if !o.spawnAllowed(sess.PersonaType) {
+			continue
+		}
+
+		if resume {
+			o.log("\n▶️  Resuming %s: %s (%s)\n", sess.PersonaType, sess.PersonaName, sess.LastExitReason)
+		} else {
+			o.log("\n🔁 Restarting %s: %s (attempt %d)\n", sess.PersonaType, sess.PersonaName, sess.RestartCount+1)
+		}
+		if err := o.spawnSession(sess, true); err != nil {
+			o.log("⚠️  Failed to restart %s: %v\n", sess.ID, err)
+			o.sm.RecordExit(sess.ID, fmt.Sprintf("restart failed: %v", err), "failed")
+			o.failedCount++
+			continue
+		}
 
-			delete(o.activeSessions, sessionID)
-			o.sm.UpdateSessionStatus(sessionID, "stopped")
-
-			// Check if it was manually killed vs completed
-			tasks, err := o.sm.ReadTasks(sessionID)
-			if err == nil && o.areAllTasksCompleted(tasks) {
-				o.log("   📋 All tasks were completed\n")
-				o.sm.UpdateSessionStatus(sessionID, "completed")
-				o.completedCount++
-			} else {
-				o.log("   📋 Session did not complete all tasks\n")
-				o.failedCount++
+		if resume {
+			if err := o.sm.RecordResume(sess.ID); err != nil {
+				o.log("⚠️  Failed to record resume: %v\n", err)
 			}
+			continue
 		}
+		if err := o.sm.RecordRestart(sess.ID); err != nil {
+			o.log("⚠️  Failed to record restart: %v\n", err)
+		}
+		o.totalSpawned++
 	}
 
 	return nil
 }
 
-// createWrapperScript creates a shell script that automatically polls and invokes Claude
-func (o *Orchestrator) createWrapperScript(sessionID, sessionDir string) string {
+// shellQuote quotes s as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// createWrapperScript creates a shell script that automatically polls and invokes Claude.
+// Claude runs in workDir; a non-empty model is passed to claude --model.
+func (o *Orchestrator) createWrapperScript(sessionID, sessionDir, workDir, model string, resumed bool) string {
 	// Get absolute path
 	absSessionDir, _ := filepath.Abs(sessionDir)
+
+	initialPrompt := "Read your tasks.md file. If you have tasks, start working on them. If waiting for instructions, check instructions.md file."
+	if resumed {
+		initialPrompt = "Your previous worker exited unexpectedly and you have been restarted. Read your tasks.md file and resume any tasks that are in progress, then check instructions.md for anything you have not handled yet."
+	}
+
 	script := fmt.Sprintf(`#!/bin/bash
 set -e
 
-SESSION_DIR="%s"
+SESSION_DIR=%s
+WORK_DIR=%s
+MODEL=%s
 cd "$SESSION_DIR"
 
+# Record the exit code so the orchestrator can apply its restart policy
+trap 'echo $? > "$SESSION_DIR/%s"' EXIT
+
 echo "🤖 Starting Claude worker for session: %s"
-echo "📂 Working directory: $SESSION_DIR"
-echo "⏰ Checking instructions every 30 seconds"
+echo "📂 Session directory: $SESSION_DIR"
+if [ "$WORK_DIR" != "$SESSION_DIR" ]; then
+    echo "🌿 Worktree: $WORK_DIR"
+fi
+if [ -n "$MODEL" ]; then
+    echo "🧠 Model: $MODEL"
+fi
+if command -v inotifywait >/dev/null 2>&1; then
+    echo "⏰ Waiting for new instructions (inotifywait, 30 second fallback)"
+else
+    echo "⏰ Checking instructions every 30 seconds"
+fi
 echo ""
 
 # Function to get file size (cross-platform)
@@ -525,18 +1020,75 @@ get_file_size() {
     fi
 }
 
+# Run Claude with JSON output, print its reply and append its token usage to the usage log
+run_claude() {
+    local output
+    output=$(cd "$WORK_DIR" && claude --print --dangerously-skip-permissions \
+        --output-format json \
+        ${MODEL:+--model "$MODEL"} \
+        --append-system-prompt "$(cat "$SESSION_DIR/persona-instructions.md")" \
+        "$1")
+
+    if command -v jq >/dev/null 2>&1 && echo "$output" | jq -e . >/dev/null 2>&1; then
+        echo "$output" | jq -r '.result // empty'
+        echo "$output" | jq -c '{
+            timestamp: (now | todate),
+            model: ((.modelUsage // {}) | to_entries | max_by(.value.outputTokens // 0) | .key // ""),
+            input_tokens: (.usage.input_tokens // 0),
+            output_tokens: (.usage.output_tokens // 0),
+            cache_read_tokens: (.usage.cache_read_input_tokens // 0),
+            cache_write_tokens: (.usage.cache_creation_input_tokens // 0),
+            cost_usd: (.total_cost_usd // 0),
+            duration_ms: (.duration_ms // 0)
+        }' >> "$SESSION_DIR/%s"
+    else
+        echo "$output"
+    fi
+}
+
+# Succeed when tasks.md has tasks and every one of them is completed
+tasks_completed() {
+    awk '
+        /^## Task:/ { tasks++ }
+        tolower($0) ~ /^[ \t]*[-*][ \t]*\*\*status\*\*[ \t]*:/ {
+            status = tolower($0)
+            sub(/^[^:]*:[ \t*_"'"'"']*/, "", status)
+            if (status !~ /^not/ && status !~ /incomplete|pending|todo|progress/ && status ~ /complete|done/) completed++
+        }
+        END { exit !(tasks > 0 && completed == tasks) }
+    ' tasks.md 2>/dev/null
+}
+
+# Stop between turns once all tasks are completed, so the orchestrator can run
+# completion gates and archive the session without interrupting a turn
+stop_if_done() {
+    if tasks_completed; then
+        echo "✅ All tasks completed - stopping worker"
+        exit 0
+    fi
+}
+
+# Wait until something in the session directory changes, or 30 seconds pass
+wait_for_changes() {
+    if command -v inotifywait >/dev/null 2>&1; then
+        inotifywait -qq -t 30 -e modify,create,moved_to "$SESSION_DIR" >/dev/null 2>&1 || true
+    else
+        sleep 30
+    fi
+}
+
 LAST_INSTRUCTIONS_SIZE=$(get_file_size "instructions.md")
+LAST_CHECKIN=$(date +%%s)
 ITERATION=0
 
 # Initial run
 echo "🎬 Initial run - reading tasks"
-claude --print --dangerously-skip-permissions \
-    --append-system-prompt "$(cat persona-instructions.md)" \
-    "Read your tasks.md file. If you have tasks, start working on them. If waiting for instructions, check instructions.md file."
+run_claude "%s"
+stop_if_done
 
 # Main monitoring loop
 while true; do
-    sleep 30
+    wait_for_changes
     ITERATION=$((ITERATION + 1))
 
     # Check if instructions.md has new content
@@ -548,215 +1100,81 @@ while true; do
             echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
 
             # Run Claude to process new instructions
-            claude --print --dangerously-skip-permissions \
-                --append-system-prompt "$(cat persona-instructions.md)" \
-                "NEW INSTRUCTIONS RECEIVED! Read instructions.md and act on them immediately. Update your tasks.md file accordingly."
+            run_claude "NEW INSTRUCTIONS RECEIVED! Read instructions.md and act on them immediately. Update your tasks.md file accordingly."
+            stop_if_done
 
             LAST_INSTRUCTIONS_SIZE=$CURRENT_SIZE
             echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
         fi
     fi
 
-    # Periodic check every 2 minutes (4 iterations) even if no changes
-    if [ $((ITERATION %% 4)) -eq 0 ]; then
+    # Periodic check every 2 minutes even if no changes
+    NOW=$(date +%%s)
+    if [ $((NOW - LAST_CHECKIN)) -ge 120 ]; then
+        LAST_CHECKIN=$NOW
         echo ""
         echo "💭 [Iteration $ITERATION] Periodic check-in (2 minutes elapsed)"
-        claude --print --dangerously-skip-permissions \
-            --append-system-prompt "$(cat persona-instructions.md)" \
-            "Status check: Review tasks.md and instructions.md. If you have work, continue. If idle and waiting, check instructions.md for new assignments. Report your status briefly."
+        run_claude "Status check: Review tasks.md and instructions.md. If you have work, continue. If idle and waiting, check instructions.md for new assignments. Report your status briefly."
+        stop_if_done
     fi
 done
-`, absSessionDir, sessionID)
+`, shellQuote(absSessionDir), shellQuote(workDir), shellQuote(model), exitStatusFile, sessionID, session.UsageLogFile, initialPrompt)
 	return script
 }
 
-// generateInstructions creates comprehensive instructions for a persona
-func (o *Orchestrator) generateInstructions(p *persona.Persona, sess *session.Session) string {
-	// Get absolute path for persona directory
+// generateInstructions renders a persona's system prompt for a session
+func (o *Orchestrator) generateInstructions(p *persona.Persona, sess *session.Session) (string, error) {
+	return p.RenderPrompt(o.promptContext(sess))
+}
+
+// promptContext collects what persona templates can refer to for a session
+func (o *Orchestrator) promptContext(sess *session.Session) persona.PromptContext {
 	absWorkspace, _ := filepath.Abs(o.workspacePath)
-	absPersonaDir := filepath.Join(absWorkspace, sess.ID)
+	ctx := persona.PromptContext{
+		Workspace:    absWorkspace,
+		SessionID:    sess.ID,
+		SessionDir:   filepath.Join(absWorkspace, sess.ID),
+		PersonaName:  sess.PersonaName,
+		PersonaType:  string(sess.PersonaType),
+		RequestTypes: o.personas.RequestTypes(),
+		Worktree:     sess.WorktreePath,
+		Branch:       sess.Branch,
+		LockFile:     filepath.Join(absWorkspace, session.LockFileName),
+	}
+	if p, err := o.personas.GetPersona(string(sess.PersonaType)); err == nil {
+		ctx.Role = p.Name
+	}
 
 	// Read CLAUDE.md if it exists for project-specific instructions
-	claudeMdPath := filepath.Join(o.workspacePath, "..", "CLAUDE.md")
-	claudeMdContent := ""
-	if data, err := os.ReadFile(claudeMdPath); err == nil {
-		claudeMdContent = fmt.Sprintf(`
-## Project Guidelines (from CLAUDE.md)
-%s
-
-`, string(data))
-	}
-
-	instructions := p.Instructions + "\n\n" + claudeMdContent + fmt.Sprintf(`
-## Your Session Information
-Session ID: %s
-Your Persona Directory: %s/
-Your Role: %s
-Working Directory: PROJECT ROOT (current directory)
-
-## IMPORTANT: Read Shell Configuration First
-Before starting work, read ~/.zshrc to discover available commands, aliases, and functions:
-- Custom functions defined by the user
-- Useful aliases and shortcuts
-- Environment-specific tools and utilities
-
-Read ~/.zshrc NOW to understand your environment.
-
-## Important: Working Directory
-- You are running from the PROJECT ROOT directory (where the project was initialized)
-- All your work (code, files, etc.) should be created in the current directory or its subdirectories
-- Your persona-specific files are in: %s/
-- Reference your persona files using the full path above
-
-## Files in Your Persona Directory
-- %s/tasks.md: YOUR task list (you update this)
-- %s/instructions.md: Instructions from others (read regularly)
-- %s/tracker.json: Reading state tracker (automatic)
-- %s/persona-instructions.md: Your role and capabilities
-
-## Important Guidelines
-
-### Automatic Instruction Monitoring
-- A background task monitors your instructions.md every 5 seconds automatically
-- When new instructions arrive, you'll be notified
-- New instructions are appended with timestamps
-
-### Update Your Tasks
-- Update %s/tasks.md with your progress after completing work
-- Use statuses: "not started", "in progress", "completed"
-- When ALL tasks are completed, you will be automatically terminated
-- The system will periodically check your progress
-
-### Communication
-- DO NOT modify other personas' files
-- To assign work: Write to their instructions.md file (see below)
-- For spawning new team members: Create request directories (see below)
-- Write your deliverables to the current directory (project root)
-- Your persona directory (%s/) is only for instructions/tasks tracking
-
-`, sess.ID, absPersonaDir, sess.PersonaName, absPersonaDir,
-	absPersonaDir, absPersonaDir, absPersonaDir, absPersonaDir,
-	absPersonaDir, absPersonaDir)
-
-	// Add communication instructions
-	instructions += fmt.Sprintf(`
-## Communicating with Other Agents
-
-You can communicate with ANY agent - there are NO hierarchy restrictions.
-Write to any agent's instructions.md file to give them tasks, ask questions, or provide feedback.
-
-To send instructions to another agent:
-1. List available agents: ls %s/*/instructions.md
-2. Append to their instructions.md file with a timestamp header
-3. They will be automatically notified within 5 seconds
-
-Examples:
-
-# Send instructions to Leader Agent
-cat >> %s/engineering-manager-*/instructions.md <<EOF
-## Instructions from %s ($(date '+%%Y-%%m-%%d %%H:%%M:%%S'))
-We need to pivot the project direction. Please review and approve.
-EOF
-
-# Send instructions to Architect
-cat >> %s/solutions-architect-*/instructions.md <<EOF
-## Instructions from %s ($(date '+%%Y-%%m-%%d %%H:%%M:%%S'))
-Please design the database schema for the user management system.
-EOF
-
-# Send instructions to any Coder
-cat >> %s/software-engineer-*/instructions.md <<EOF
-## Instructions from %s ($(date '+%%Y-%%m-%%d %%H:%%M:%%S'))
-Implement the API endpoints according to the spec.
-EOF
-
-`, absWorkspace, absWorkspace, sess.PersonaName, absWorkspace, sess.PersonaName, absWorkspace, sess.PersonaName)
-
-	// Add resource request instructions - ANY agent can request ANY resource
-	instructions += fmt.Sprintf(`
-## Requesting Additional Resources
-
-ANY agent can request ANY type of resource - there are NO restrictions.
-Need an architect? Request one. Need the leader's input? Request a conversation.
-
-To request a new agent:
-1. Create directory: %s/{agent-type}-request-{descriptive-name}/
-2. Create: instructions.md in that directory with their initial task
-3. Orchestrator will spawn the agent automatically
-4. Directory will be renamed to {agent-type}-{timestamp}/
-
-Available agent types:
-- solutions-architect-request-* → Architecture Agent
-- software-engineer-request-* → Coding Agent
-- qa-request-* → QA Agent
-- intern-request-* → Support Agent
-
-Examples:
-
-# Request an Architect
-mkdir %s/solutions-architect-request-api-designer
-cat > %s/solutions-architect-request-api-designer/instructions.md <<EOF
-Design the REST API architecture for our user management system.
-EOF
-
-# Request a Coder
-mkdir %s/software-engineer-request-backend
-cat > %s/software-engineer-request-backend/instructions.md <<EOF
-Implement the backend API endpoints according to the architecture spec.
-EOF
-
-# Request QA
-mkdir %s/qa-request-api-tester
-cat > %s/qa-request-api-tester/instructions.md <<EOF
-Write integration tests for the user management API.
-EOF
-
-# Request Support
-mkdir %s/intern-request-documentation
-cat > %s/intern-request-documentation/instructions.md <<EOF
-Write API documentation for all endpoints in OpenAPI format.
-EOF
-
-`, absWorkspace, absWorkspace, absWorkspace, absWorkspace, absWorkspace, absWorkspace, absWorkspace, absWorkspace, absWorkspace)
-
-	instructions += `
-## Completion
-When all your tasks are marked "completed", you will be automatically terminated and your work will be archived.
-`
-
-	// Add background task instructions
-	instructions += fmt.Sprintf(`
-## IMPORTANT: Background Tasks
-
-Start these two background tasks IMMEDIATELY when you begin:
-
-### Task 1: Instruction Monitoring
-Monitor your instructions.md file every 5 seconds. When new instructions arrive (file size increases), READ AND ACT ON THEM IMMEDIATELY.
-
-Bash(PERSONA_DIR=%s; LAST_SIZE=0; while true; do if [ -f "$PERSONA_DIR/instructions.md" ]; then NEW_SIZE=$(wc -c < "$PERSONA_DIR/instructions.md" | tr -d " "); if [ "$NEW_SIZE" -gt "${LAST_SIZE:-0}" 2>/dev/null ]; then echo "🔔 NEW INSTRUCTIONS DETECTED! File grew from $LAST_SIZE to $NEW_SIZE bytes. READ instructions.md NOW and act on new tasks!"; fi; LAST_SIZE=$NEW_SIZE; fi; sleep 5; done, run_in_background=true)
-
-### Task 2: Status Updates
-Update your session.json with current_work every 10 seconds. Extract just the task title from tasks.md (details shown in popup).
-
-Bash(PERSONA_DIR=%s; while true; do CURRENT=$(grep '^## Task:' $PERSONA_DIR/tasks.md 2>/dev/null | head -1 | sed 's/^## Task: //' || echo "No tasks assigned"); jq --arg status "$CURRENT" '.current_work = $status' $PERSONA_DIR/session.json > $PERSONA_DIR/session.tmp && mv $PERSONA_DIR/session.tmp $PERSONA_DIR/session.json; sleep 10; done, run_in_background=true)
-
-## CRITICAL: After Completing Tasks
-
-When you complete all your current tasks:
-1. IMMEDIATELY use Read tool to read instructions.md and check for new assignments
-2. If new instructions found, start working on them right away
-3. Before declaring yourself done or idle, ALWAYS check instructions.md one more time
-4. If no new instructions, update tasks.md with "Waiting for instructions" status
-
-**IMPORTANT**: Similar to how you send notifications after responses, you MUST check instructions.md after completing each task. This is not optional.
+	if data, err := os.ReadFile(filepath.Join(o.workspacePath, "..", "CLAUDE.md")); err == nil {
+		ctx.Guidelines = strings.TrimSpace(string(data))
+	}
 
-## Startup Sequence
-1. Read ~/.zshrc to discover available commands and functions
-2. Start both background tasks above
-3. Begin working on your tasks from %s/tasks.md
-`, absPersonaDir, absPersonaDir, absPersonaDir)
+	if tasks, err := o.sm.LoadTasks(sess.ID); err == nil {
+		task := tasks.FirstWithStatus(session.TaskStatusInProgress)
+		if task == nil {
+			task = tasks.FirstWithStatus(session.TaskStatusNotStarted)
+		}
+		if task != nil {
+			ctx.Task = task.Description
+		}
+	}
 
-	return instructions
+	if active, err := o.sm.GetActiveSessions(); err == nil {
+		for _, other := range active {
+			if other.ID == sess.ID {
+				continue
+			}
+			ctx.Teammates = append(ctx.Teammates, persona.Teammate{
+				ID:          other.ID,
+				Name:        other.PersonaName,
+				Type:        string(other.PersonaType),
+				Status:      other.Status,
+				CurrentWork: other.CurrentWork,
+			})
+		}
+	}
+	return ctx
 }
 
 // GetStatus returns current orchestrator status
@@ -772,8 +1190,7 @@ func (o *Orchestrator) GetStatus() (string, error) {
 	status += fmt.Sprintf("Total Sessions: %d\n\n", len(sessions))
 
 	for sessionID := range o.activeSessions {
-		tmuxSessionName := fmt.Sprintf("claude-%s", sessionID)
-		status += fmt.Sprintf("  %s (tmux: %s)\n", sessionID, tmuxSessionName)
+		status += fmt.Sprintf("  %s (%s: %s)\n", sessionID, o.backendFor(sessionID).Name(), backend.ProcessName(sessionID))
 	}
 
 	return status, nil
@@ -799,6 +1216,10 @@ func (o *Orchestrator) loadState() error {
 		o.spawnedSessions = state.SpawnedSessions
 	}
 
+	// Restore the spawn queue so waiting requests keep their place
+	o.spawnQueue = state.SpawnQueue
+	o.sortSpawnQueue()
+
 	return nil
 }
 
@@ -814,6 +1235,9 @@ func (o *Orchestrator) saveState() error {
 		FailedSessions:      o.failedCount,
 		TmuxSession:         o.tmuxSession,
 		SpawnedSessions:     o.spawnedSessions,
+		SpawningPaused:      o.spawningPaused,
+		BlockedTasks:        o.blockedTaskKeys(),
+		SpawnQueue:          o.spawnQueue,
 	}
 
 	stateFile := filepath.Join(o.workspacePath, "orchestrator", "state.json")
@@ -822,12 +1246,29 @@ func (o *Orchestrator) saveState() error {
 		return err
 	}
 
-	return os.WriteFile(stateFile, data, 0644)
+	return session.WriteFileAtomic(stateFile, data, 0644)
+}
+
+// blockedTaskKeys returns the blocked task keys in a stable order
+func (o *Orchestrator) blockedTaskKeys() []string {
+	keys := make([]string, 0, len(o.blockedTasks))
+	for key := range o.blockedTasks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // generateCurrentWork creates a concise status message
 func (o *Orchestrator) generateCurrentWork() string {
+	if o.spawningPaused != "" {
+		return "Spawning paused: " + o.spawningPaused
+	}
+
 	activeCount := len(o.activeSessions)
+	if len(o.spawnQueue) > 0 {
+		return fmt.Sprintf("Monitoring %d sessions, %d queued", activeCount, len(o.spawnQueue))
+	}
 	if activeCount == 0 {
 		return "Waiting for sessions to spawn"
 	}
@@ -837,15 +1278,14 @@ func (o *Orchestrator) generateCurrentWork() string {
 	return fmt.Sprintf("Monitoring %d sessions", activeCount)
 }
 
-// KillAllSessions kills all spawned tmux sessions including the orchestrator
+// KillAllSessions kills all spawned worker processes including the orchestrator's tmux session
 func (o *Orchestrator) KillAllSessions() error {
 	killed := 0
 	failed := 0
 
 	// Kill all spawned agent sessions
-	for _, tmuxSession := range o.spawnedSessions {
-		cmd := exec.Command("tmux", "kill-session", "-t", tmuxSession)
-		if err := cmd.Run(); err != nil {
+	for _, processName := range o.spawnedSessions {
+		if err := o.backendFor(backend.SessionID(processName)).Kill(processName); err != nil {
 			// Session might already be dead, that's ok
 			failed++
 		} else {
@@ -855,8 +1295,7 @@ func (o *Orchestrator) KillAllSessions() error {
 
 	// Kill the orchestrator's own tmux session if it exists
 	if o.tmuxSession != "" {
-		cmd := exec.Command("tmux", "kill-session", "-t", o.tmuxSession)
-		if err := cmd.Run(); err != nil {
+		if err := backend.NewTmuxBackend().Kill(o.tmuxSession); err != nil {
 			failed++
 		} else {
 			killed++
@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/persona"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+func TestKillAllSessionsUsesEachSessionsBackend(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.backend = backend.NewTmuxBackend()
+
+	// A worker adopted from an orchestrator that ran the process backend
+	sess, err := o.sm.CreateSession(session.SessionTypeSoftwareEngineer, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(t.TempDir(), "worker.sh")
+	if err := os.WriteFile(script, []byte("sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	processes := backend.NewProcessBackend(backend.StateDir(o.workspacePath))
+	name := backend.ProcessName(sess.ID)
+	if err := processes.Spawn(name, script, t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer processes.Kill(name)
+	if err := o.sm.UpdateWorkerProcess(sess.ID, backend.NameProcess, name, "", true); err != nil {
+		t.Fatal(err)
+	}
+	o.trackSpawned(name)
+
+	if err := o.KillAllSessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for processes.IsAlive(name) && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if processes.IsAlive(name) {
+		t.Error("worker from another backend survived KillAllSessions")
+	}
+}
+
+func TestSpawnRequestsRouteByConfiguredPersonaType(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Approval.Enabled = true
+	personas := persona.DefaultPersonas()
+	personas.Personas["data-engineer"] = persona.Persona{Name: "Data Engineer"}
+	o.personas = &personas
+
+	manager, err := o.sm.CreateSession(session.SessionTypeEngineeringManager, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeRequest(t, o, "data-engineer-request-etl", "requester: "+manager.ID+"\n")
+	writeRequest(t, o, "security-auditor-request-pen-test", "requester: "+manager.ID+"\n")
+
+	// A custom persona type is queued for approval under its own type
+	if err := o.handleSpawnRequest("data-engineer-request-etl"); err != nil {
+		t.Fatal(err)
+	}
+	if req := queuedRequest(t, o, "data-engineer-request-etl"); req == nil || req.PersonaType != "data-engineer" {
+		t.Errorf("queued request = %+v, want a data-engineer request", req)
+	}
+
+	// An unconfigured type is rejected and the requester told which types exist
+	if err := o.handleSpawnRequest("security-auditor-request-pen-test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(o.workspacePath, "security-auditor-request-pen-test")); !os.IsNotExist(err) {
+		t.Errorf("rejected request directory still exists (err = %v)", err)
+	}
+	inbox, err := o.sm.ReadInbox(manager.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox) != 1 || !strings.Contains(inbox[0].Content, "no 'security-auditor' persona") || !strings.Contains(inbox[0].Content, "data-engineer") {
+		t.Errorf("requester inbox = %+v, want the rejection listing data-engineer", inbox)
+	}
+}
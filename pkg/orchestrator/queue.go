@@ -0,0 +1,132 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// QueuedSpawn is a spawn request waiting for a free slot
+type QueuedSpawn struct {
+	ID          string              `json:"id"` // Request directory name
+	PersonaType session.SessionType `json:"persona_type"`
+	Priority    int                 `json:"priority,omitempty"`
+	QueuedAt    time.Time           `json:"queued_at"`
+	Reason      string              `json:"reason"` // Limit that is holding the request
+}
+
+// capacityFor reports whether another session of a persona type may start, or
+// which limit is full
+func (o *Orchestrator) capacityFor(personaType session.SessionType) (string, bool) {
+	limits := o.cfg.Limits
+	perType := limits.Personas[string(personaType)]
+	if limits.MaxTotal <= 0 && perType <= 0 {
+		return "", true
+	}
+
+	sessions, err := o.sm.GetAllSessions()
+	if err != nil {
+		return "", true
+	}
+	total, ofType := 0, 0
+	for _, sess := range sessions {
+		if !sess.Running() {
+			continue
+		}
+		total++
+		if sess.PersonaType == personaType {
+			ofType++
+		}
+	}
+
+	if perType > 0 && ofType >= perType {
+		return fmt.Sprintf("%d/%d %s sessions active", ofType, perType, personaType), false
+	}
+	if limits.MaxTotal > 0 && total >= limits.MaxTotal {
+		return fmt.Sprintf("%d/%d sessions active", total, limits.MaxTotal), false
+	}
+	return "", true
+}
+
+// enqueueSpawn adds a request to the spawn queue, or updates why it is waiting
+func (o *Orchestrator) enqueueSpawn(dirName string, personaType session.SessionType, reason string) {
+	for _, q := range o.spawnQueue {
+		if q.ID == dirName {
+			q.Reason = reason
+			return
+		}
+	}
+
+	o.spawnQueue = append(o.spawnQueue, &QueuedSpawn{
+		ID:          dirName,
+		PersonaType: personaType,
+		Priority:    o.queuePriority(dirName, personaType),
+		QueuedAt:    time.Now(),
+		Reason:      reason,
+	})
+	o.sortSpawnQueue()
+	o.log("\n⏳ Queued %s (%s), position %d\n", dirName, reason, o.queuePosition(dirName))
+}
+
+// dequeueSpawn removes a request from the spawn queue
+func (o *Orchestrator) dequeueSpawn(dirName string) {
+	for i, q := range o.spawnQueue {
+		if q.ID == dirName {
+			o.spawnQueue = append(o.spawnQueue[:i], o.spawnQueue[i+1:]...)
+			return
+		}
+	}
+}
+
+// queuePosition returns a request's 1-based position in the spawn queue
+func (o *Orchestrator) queuePosition(dirName string) int {
+	for i, q := range o.spawnQueue {
+		if q.ID == dirName {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// queuePriority returns the priority a request waits with: the priority set in
+// its request.yaml, or with order: priority the priority of its persona type
+func (o *Orchestrator) queuePriority(dirName string, personaType session.SessionType) int {
+	if p, ok := o.requestPriority(dirName); ok {
+		return p
+	}
+	if o.cfg.Limits.Order == config.QueuePriority {
+		return o.cfg.Limits.Priorities[string(personaType)]
+	}
+	return session.PriorityNormal
+}
+
+// sortSpawnQueue orders the queue by priority, then oldest first
+func (o *Orchestrator) sortSpawnQueue() {
+	sort.SliceStable(o.spawnQueue, func(i, j int) bool {
+		a, b := o.spawnQueue[i], o.spawnQueue[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.QueuedAt.Before(b.QueuedAt)
+	})
+}
+
+// processSpawnQueue retries queued requests in queue order, dropping those
+// whose directory has gone
+func (o *Orchestrator) processSpawnQueue() {
+	queue := append([]*QueuedSpawn(nil), o.spawnQueue...)
+	for _, q := range queue {
+		if _, err := os.Stat(filepath.Join(o.workspacePath, q.ID)); err != nil {
+			o.dequeueSpawn(q.ID)
+			continue
+		}
+		if err := o.handleSpawnRequest(q.ID); err != nil {
+			o.log("⚠️  Failed to handle spawn request %s: %v\n", q.ID, err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// newTestOrchestrator creates a quiet orchestrator on a temporary workspace
+func newTestOrchestrator(t *testing.T, limits config.LimitsConfig) *Orchestrator {
+	t.Helper()
+	workspace := t.TempDir()
+	sm, err := session.NewSessionManager(workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Orchestrator{
+		sm:               sm,
+		cfg:              &config.Config{Limits: limits},
+		workspacePath:    workspace,
+		tuiMode:          true,
+		activeSessions:   make(map[string]bool),
+		budgetWarnings:   make(map[string]bool),
+		requestWarnings:  make(map[string]bool),
+		offeredTasks:     make(map[string]time.Time),
+		gatesPassed:      make(map[string]bool),
+		checks:           make(map[string]*backgroundCheck),
+		checkFinished:    make(chan string, 16),
+		signoffRequested: make(map[string]bool),
+	}
+}
+
+// writeRequest creates a request directory, with a request.yaml when meta is set
+func writeRequest(t *testing.T, o *Orchestrator, dirName, meta string) {
+	t.Helper()
+	dir := filepath.Join(o.workspacePath, dirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if meta != "" {
+		if err := os.WriteFile(filepath.Join(dir, session.RequestMetaFile), []byte(meta), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func queueIDs(o *Orchestrator) []string {
+	var ids []string
+	for _, q := range o.spawnQueue {
+		ids = append(ids, q.ID)
+	}
+	return ids
+}
+
+func TestSpawnQueueOrdersByRequestPriorityThenAge(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+
+	writeRequest(t, o, "intern-request-docs", "")
+	writeRequest(t, o, "qa-request-smoke", "priority: low\n")
+	writeRequest(t, o, "software-engineer-request-api", "priority: urgent\n")
+	writeRequest(t, o, "software-engineer-request-ui", "")
+
+	o.enqueueSpawn("intern-request-docs", session.SessionTypeIntern, "full")
+	time.Sleep(time.Millisecond)
+	o.enqueueSpawn("qa-request-smoke", session.SessionTypeQA, "full")
+	time.Sleep(time.Millisecond)
+	o.enqueueSpawn("software-engineer-request-api", session.SessionTypeSoftwareEngineer, "full")
+	time.Sleep(time.Millisecond)
+	o.enqueueSpawn("software-engineer-request-ui", session.SessionTypeSoftwareEngineer, "full")
+
+	want := []string{"software-engineer-request-api", "intern-request-docs", "software-engineer-request-ui", "qa-request-smoke"}
+	if got := queueIDs(o); !equalStrings(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+	if pos := o.queuePosition("intern-request-docs"); pos != 2 {
+		t.Errorf("queuePosition = %d, want 2", pos)
+	}
+
+	// Re-queueing updates the reason without moving the request
+	o.enqueueSpawn("intern-request-docs", session.SessionTypeIntern, "still full")
+	if len(o.spawnQueue) != 4 || o.spawnQueue[1].Reason != "still full" {
+		t.Errorf("re-queue changed the queue: %v", queueIDs(o))
+	}
+
+	o.dequeueSpawn("software-engineer-request-api")
+	if pos := o.queuePosition("software-engineer-request-api"); pos != 0 {
+		t.Errorf("dequeued request still at position %d", pos)
+	}
+	if o.queuePosition("intern-request-docs") != 1 {
+		t.Errorf("queue after dequeue = %v", queueIDs(o))
+	}
+}
+
+func TestSpawnQueuePersonaPriorities(t *testing.T) {
+	limits := config.LimitsConfig{
+		Order:      config.QueuePriority,
+		Priorities: map[string]int{"software-engineer": 1},
+	}
+	o := newTestOrchestrator(t, limits)
+
+	writeRequest(t, o, "intern-request-docs", "")
+	writeRequest(t, o, "software-engineer-request-api", "")
+	writeRequest(t, o, "qa-request-release", "priority: urgent\n")
+
+	o.enqueueSpawn("intern-request-docs", session.SessionTypeIntern, "full")
+	time.Sleep(time.Millisecond)
+	o.enqueueSpawn("software-engineer-request-api", session.SessionTypeSoftwareEngineer, "full")
+	time.Sleep(time.Millisecond)
+	o.enqueueSpawn("qa-request-release", session.SessionTypeQA, "full")
+
+	want := []string{"qa-request-release", "software-engineer-request-api", "intern-request-docs"}
+	if got := queueIDs(o); !equalStrings(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+
+	// In fifo order persona priorities are ignored
+	o.cfg.Limits.Order = config.QueueFIFO
+	if p := o.queuePriority("software-engineer-request-api", session.SessionTypeSoftwareEngineer); p != session.PriorityNormal {
+		t.Errorf("fifo queuePriority = %d, want normal", p)
+	}
+}
+
+func TestCapacityFor(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{
+		MaxTotal: 2,
+		Personas: map[string]int{"intern": 1},
+	})
+
+	if _, ok := o.capacityFor(session.SessionTypeIntern); !ok {
+		t.Fatal("no capacity in an empty workspace")
+	}
+
+	if _, err := o.sm.CreateSession(session.SessionTypeIntern, "", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+	if reason, ok := o.capacityFor(session.SessionTypeIntern); ok || reason != "1/1 intern sessions active" {
+		t.Errorf("capacityFor(intern) = %q, %v", reason, ok)
+	}
+	if _, ok := o.capacityFor(session.SessionTypeSoftwareEngineer); !ok {
+		t.Error("engineer blocked by the intern limit")
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if _, err := o.sm.CreateSession(session.SessionTypeSoftwareEngineer, "", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+	if reason, ok := o.capacityFor(session.SessionTypeQA); ok || reason != "2/2 sessions active" {
+		t.Errorf("capacityFor(qa) = %q, %v", reason, ok)
+	}
+}
+
+func TestProcessSpawnQueueDropsRemovedRequests(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.enqueueSpawn("intern-request-gone", session.SessionTypeIntern, "full")
+
+	o.processSpawnQueue()
+
+	if len(o.spawnQueue) != 0 {
+		t.Errorf("queue = %v, want empty", queueIDs(o))
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInvalidRequestMetaKeepsRequester(t *testing.T) {
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	writeRequest(t, o, "qa-request-1", "priority: [high\n")
+	if err := os.WriteFile(filepath.Join(o.workspacePath, "qa-request-1", session.RequesterFile), []byte("engineering-manager-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := o.loadRequestMeta("qa-request-1")
+	if meta.Requester != "engineering-manager-1" {
+		t.Errorf("requester = %q, want the requester file's", meta.Requester)
+	}
+	if !o.requestWarnings["request-meta:qa-request-1"] {
+		t.Error("invalid request.yaml not reported")
+	}
+	if len(o.budgetWarnings) != 0 {
+		t.Errorf("request warnings recorded as budget warnings: %v", o.budgetWarnings)
+	}
+}
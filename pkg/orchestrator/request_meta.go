@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// loadRequestMeta reads a request directory's request.yaml. Invalid metadata is
+// reported once and ignored so a typo does not hold up the spawn; the requester
+// file still names the requester.
+func (o *Orchestrator) loadRequestMeta(dirName string) *session.RequestMeta {
+	meta, err := session.LoadRequestMeta(filepath.Join(o.workspacePath, dirName))
+	if err != nil {
+		if key := "request-meta:" + dirName; !o.requestWarnings[key] {
+			o.requestWarnings[key] = true
+			o.log("⚠️  Ignoring %s/%s: %v\n", dirName, session.RequestMetaFile, err)
+		}
+	}
+	return meta
+}
+
+// requestPriority returns the priority set in a request's request.yaml
+func (o *Orchestrator) requestPriority(dirName string) (int, bool) {
+	p, ok, err := o.loadRequestMeta(dirName).PriorityValue()
+	if err != nil {
+		if key := "request-priority:" + dirName; !o.requestWarnings[key] {
+			o.requestWarnings[key] = true
+			o.log("⚠️  Ignoring priority of %s: %v\n", dirName, err)
+		}
+	}
+	return p, ok
+}
+
+// sortRequestsByPriority orders request directories found in the same scan by
+// requested priority, then oldest first
+func (o *Orchestrator) sortRequestsByPriority(dirNames []string) {
+	priority := make(map[string]int, len(dirNames))
+	modTime := make(map[string]int64, len(dirNames))
+	for _, name := range dirNames {
+		priority[name], _ = o.requestPriority(name)
+		if info, err := os.Stat(filepath.Join(o.workspacePath, name)); err == nil {
+			modTime[name] = info.ModTime().UnixNano()
+		}
+	}
+	sort.SliceStable(dirNames, func(i, j int) bool {
+		a, b := dirNames[i], dirNames[j]
+		if priority[a] != priority[b] {
+			return priority[a] > priority[b]
+		}
+		return modTime[a] < modTime[b]
+	})
+}
+
+// applyRequestMeta records a request's metadata on the session spawned for it
+func (o *Orchestrator) applyRequestMeta(sess *session.Session, meta *session.RequestMeta) {
+	priority, _, _ := meta.PriorityValue()
+	deadline, err := meta.DeadlineTime()
+	if err != nil {
+		o.log("⚠️  Ignoring deadline of %s's request: %v\n", sess.ID, err)
+	}
+
+	update := func(s *session.Session) {
+		s.Priority = priority
+		s.Deadline = deadline
+		s.RequestedModel = strings.TrimSpace(meta.Model)
+		s.BudgetUSD = meta.Budget.USD
+		s.BudgetTokens = meta.Budget.Tokens
+		s.Labels = meta.Labels
+	}
+	update(sess)
+	if err := o.sm.UpdateSession(sess.ID, update); err != nil {
+		o.log("⚠️  Failed to record request metadata: %v\n", err)
+	}
+}
+
+// requestMetaNote tells a new session the priority and deadline of its request
+func requestMetaNote(sess *session.Session) string {
+	var parts []string
+	if sess.Priority != session.PriorityNormal {
+		parts = append(parts, "- **Priority**: "+session.PriorityName(sess.Priority))
+	}
+	if !sess.Deadline.IsZero() {
+		parts = append(parts, "- **Deadline**: "+sess.Deadline.Format(session.TaskTimeFormat))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(parts, "\n") +
+		"\n\nAdd the same Priority and Deadline fields to the tasks you create for this request."
+}
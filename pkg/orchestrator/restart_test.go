@@ -0,0 +1,137 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
+	"github.com/tarzzz/wildwest/pkg/persona"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// newRestartOrchestrator creates a quiet orchestrator that spawns workers as
+// subprocesses and kills them when the test ends
+func newRestartOrchestrator(t *testing.T, restart config.RestartConfig) *Orchestrator {
+	t.Helper()
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+	// A claude that does nothing keeps workers idle until they are killed
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	o := newTestOrchestrator(t, config.LimitsConfig{})
+	o.cfg.Restart = restart
+	defaults := persona.DefaultPersonas()
+	o.personas = &defaults
+	o.backend = backend.NewProcessBackend(backend.StateDir(o.workspacePath))
+	t.Cleanup(func() {
+		var groups []int
+		for _, processName := range o.spawnedSessions {
+			groups = append(groups, workerGroup(o, processName))
+		}
+		o.KillAllSessions()
+		for _, pgid := range groups {
+			waitForExit(pgid)
+		}
+	})
+	return o
+}
+
+// workerGroup returns the process group of a worker, or 0 if it has none.
+// Kill forgets the pid, so it has to be read beforehand.
+func workerGroup(o *Orchestrator, processName string) int {
+	data, err := os.ReadFile(filepath.Join(backend.StateDir(o.workspacePath), processName+".pid"))
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
+
+// waitForExit waits for a killed worker's process group to exit, so it stops
+// writing into the workspace
+func waitForExit(pgid int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for pgid > 0 && syscall.Kill(-pgid, 0) == nil && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// exitedSession creates a session whose worker exited with the given status
+func exitedSession(t *testing.T, o *Orchestrator, status string) *session.Session {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond)
+	sess, err := o.sm.CreateSession(session.SessionTypeSoftwareEngineer, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.sm.RecordExit(sess.ID, "test exit", status); err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestResumeIsNotARestart(t *testing.T) {
+	o := newRestartOrchestrator(t, config.RestartConfig{
+		Default: config.RestartPolicy{Policy: config.RestartOnFailure, Backoff: time.Hour},
+	})
+	crashed := exitedSession(t, o, "restarting")
+	reopened := exitedSession(t, o, "resuming")
+
+	if err := o.processRestarts(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The crashed worker waits for its backoff; the reopened one is resumed at once
+	if o.activeSessions[crashed.ID] {
+		t.Error("crashed session restarted before its backoff elapsed")
+	}
+	if !o.activeSessions[reopened.ID] {
+		t.Fatal("reopened session was not resumed")
+	}
+	got, err := o.sm.GetSession(reopened.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "active" || got.RestartCount != 0 {
+		t.Errorf("resumed session status = %s, restarts = %d; want active with no restarts", got.Status, got.RestartCount)
+	}
+}
+
+func TestCrashAfterResumeFollowsPolicy(t *testing.T) {
+	o := newRestartOrchestrator(t, config.RestartConfig{})
+	sess := exitedSession(t, o, "resuming")
+	if err := o.sm.AddTask(sess.ID, "Fix the failing gate", "system"); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.processRestarts(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The resumed worker crashes; under the default never policy it just stops
+	pgid := workerGroup(o, backend.ProcessName(sess.ID))
+	o.backend.Kill(backend.ProcessName(sess.ID))
+	waitForExit(pgid)
+	if err := o.monitorRunningSessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := o.sm.GetSession(sess.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "stopped" || got.RestartCount != 0 {
+		t.Errorf("session after crash = %s (%s), restarts = %d; want stopped", got.Status, got.LastExitReason, got.RestartCount)
+	}
+}
@@ -2,12 +2,11 @@ package orchestrator
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tarzzz/wildwest/pkg/backend"
 	"github.com/tarzzz/wildwest/pkg/session"
 )
 
@@ -201,18 +200,14 @@ func runSessionWithBackNavigation(workspacePath, version string) (bool, error) {
 				return true, nil
 			}
 
-			// Check if we need to attach to a tmux session
+			// Check if we need to attach to a worker process
 			if m.attachToSession != "" {
-				cmd := exec.Command("bash", "-c", fmt.Sprintf("clear && tmux attach -t %s", m.attachToSession))
-				cmd.Stdin = os.Stdin
-				cmd.Stdout = os.Stdout
-				cmd.Stderr = os.Stderr
-				err := cmd.Run()
-				if err != nil {
-					fmt.Printf("Error attaching to tmux: %v\nPress Enter to return to TUI...", err)
+				b := backend.ForSession(m.attachBackend, workspacePath)
+				if err := b.Attach(m.attachToSession); err != nil {
+					fmt.Printf("Error attaching to %s: %v\nPress Enter to return to TUI...", m.attachToSession, err)
 					fmt.Scanln()
 				}
-				// After detaching from tmux, loop back to TUI
+				// After detaching, loop back to TUI
 				continue
 			}
 		}
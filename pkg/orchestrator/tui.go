@@ -11,6 +11,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tarzzz/wildwest/pkg/backend"
+	"github.com/tarzzz/wildwest/pkg/config"
 	"github.com/tarzzz/wildwest/pkg/session"
 )
 
@@ -28,6 +30,14 @@ type Component struct {
 	StatusMessage string // Brief statement about what they're doing
 	TmuxSpawned   bool   // Whether tmux session is spawned
 	TmuxSession   string // Tmux session name
+	Backend       string // Process backend running the session
+	Branch        string // Git branch of a worktree-isolated session
+	MergeStatus   string // Result of merging the branch
+	MergeDetail   string // Summary of the last merge attempt
+	Priority      int       // Priority of the session's spawn request
+	Deadline      time.Time // Deadline of the session's spawn request
+	Overdue       int       // Unfinished tasks (and the request itself) past their deadline
+	ParentID      string    // Session that requested this one
 }
 
 // OrgChartModel is the TUI model for a static org chart
@@ -45,9 +55,14 @@ type OrgChartModel struct {
 	maxLogs          int
 	tickCount        int  // Track ticks for less frequent updates
 	initialized      bool // Track if we've done initial load
-	attachToSession  string // Tmux session to attach to on exit
+	attachToSession  string // Worker process to attach to on exit
+	attachBackend    string // Backend of the worker process to attach to
 	version          string // Version info for display
 	goBack           bool   // Signal to return to session selector
+	composing        bool   // Whether a message to the selected component is being typed
+	composeBuffer    string // Message being typed
+	spawnQueue       []*QueuedSpawn // Requests waiting for capacity, from orchestrator state
+	burnRate         float64        // Team spend per hour, from the cost history
 }
 
 // Styles
@@ -165,6 +180,10 @@ func tickCmd() tea.Cmd {
 func (m OrgChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.composing {
+			return m.updateCompose(msg)
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
@@ -194,10 +213,19 @@ func (m OrgChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				comp := m.components[m.selectedIndex]
 				if comp.TmuxSpawned && comp.TmuxSession != "" {
 					m.attachToSession = comp.TmuxSession
+					m.attachBackend = comp.Backend
 					return m, tea.Quit
 				}
 			}
 
+		case "m":
+			// Compose a message to the selected component
+			if m.selectedIndex >= 0 && m.selectedIndex < len(m.components) &&
+				m.components[m.selectedIndex].ID != "orchestrator" {
+				m.composing = true
+				m.composeBuffer = ""
+			}
+
 		case "K":
 			// Kill session and delete database files
 			return m, m.killSession()
@@ -222,6 +250,7 @@ func (m OrgChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.addLog(fmt.Sprintf("Found %d sessions", len(sessions)))
 					m.activeSessions = sessions
 					m.updateComponentsFromSessions()
+					m.refreshBurnRate()
 					m.addLog(fmt.Sprintf("Created %d components", len(m.components)))
 					if len(sessions) > 0 {
 						m.addLog(m.generateStatusSummary())
@@ -239,6 +268,7 @@ func (m OrgChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Only refresh sessions every 3 ticks (6 seconds) to avoid blocking UI
 		if m.tickCount%3 == 0 && m.sessionManager != nil {
+			m.refreshBurnRate()
 			sessions, err := m.sessionManager.GetActiveSessions()
 			if err == nil {
 				oldCount := len(m.activeSessions)
@@ -276,6 +306,45 @@ func (m OrgChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateCompose handles key presses while a message is being typed
+func (m OrgChartModel) updateCompose(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.composing = false
+		m.composeBuffer = ""
+
+	case tea.KeyEnter:
+		m.composing = false
+		text := strings.TrimSpace(m.composeBuffer)
+		m.composeBuffer = ""
+		if text == "" || m.selectedIndex >= len(m.components) {
+			break
+		}
+		comp := m.components[m.selectedIndex]
+		if err := m.sessionManager.WriteInstructions("user", comp.ID, text); err != nil {
+			m.addLog(fmt.Sprintf("Failed to send to %s: %v", comp.Name, err))
+		} else {
+			m.addLog(fmt.Sprintf("📨 Sent message to %s", comp.Name))
+		}
+
+	case tea.KeyBackspace:
+		if runes := []rune(m.composeBuffer); len(runes) > 0 {
+			m.composeBuffer = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeySpace:
+		m.composeBuffer += " "
+
+	case tea.KeyRunes:
+		m.composeBuffer += string(msg.Runes)
+	}
+
+	return m, nil
+}
+
 // addLog adds a log message with timestamp
 func (m *OrgChartModel) addLog(message string) {
 	timestamp := time.Now().Format("15:04:05")
@@ -304,11 +373,13 @@ func (m *OrgChartModel) loadOrchestratorState() {
 		CurrentWork    string `json:"current_work"`
 		ActiveSessions int    `json:"active_sessions"`
 		TmuxSession    string `json:"tmux_session,omitempty"`
+		SpawnQueue     []*QueuedSpawn `json:"spawn_queue,omitempty"`
 	}
 
 	if err := json.Unmarshal(data, &orch); err != nil {
 		return
 	}
+	m.spawnQueue = orch.SpawnQueue
 
 	// Check if orchestrator tmux session is actually running
 	tmuxSpawned := false
@@ -372,6 +443,22 @@ func (m *OrgChartModel) updateComponentsFromSessions() {
 			Status:      m.mapSessionStatus(sess.Status),
 			TmuxSpawned: sess.TmuxSpawned,
 			TmuxSession: sess.TmuxSession,
+			Backend:     sess.Backend,
+			Branch:      sess.Branch,
+			MergeStatus: sess.MergeStatus,
+			MergeDetail: sess.MergeDetail,
+			Priority:    sess.Priority,
+			Deadline:    sess.Deadline,
+			ParentID:    sess.ParentSessionID,
+		}
+
+		// Flag work that has missed its deadline
+		now := time.Now()
+		if sess.Overdue(now) {
+			comp.Overdue++
+		}
+		if tasks, err := m.sessionManager.LoadTasks(sess.ID); err == nil {
+			comp.Overdue += tasks.CountOverdue(now)
 		}
 
 		// Use current_work from session.json if available
@@ -392,8 +479,70 @@ func (m *OrgChartModel) updateComponentsFromSessions() {
 		m.components = append(m.components, comp)
 	}
 
-	// Sort by persona type hierarchy (Manager, Architect, QA, Engineers, Interns)
+	// Sort by persona type hierarchy (Manager, Architect, QA, Engineers, Interns),
+	// then nest each session under the session that requested it
 	m.sortComponentsByHierarchy()
+	m.components = orderAsTree(m.components)
+}
+
+// componentChildren groups component indexes by the ID of their parent; roots
+// are listed under ""
+func componentChildren(components []Component) map[string][]int {
+	present := make(map[string]bool, len(components))
+	for _, comp := range components {
+		present[comp.ID] = true
+	}
+	children := make(map[string][]int)
+	for i, comp := range components {
+		parent := comp.ParentID
+		if !present[parent] || parent == comp.ID {
+			parent = ""
+		}
+		children[parent] = append(children[parent], i)
+	}
+	return children
+}
+
+// walkTree visits components depth first from the roots, passing each one's
+// indentation and whether it is the last of its siblings
+func walkTree(components []Component, visit func(i int, indent string, last bool)) {
+	children := componentChildren(components)
+	visited := make(map[int]bool, len(components))
+	var walk func(parent, indent string)
+	walk = func(parent, indent string) {
+		kids := children[parent]
+		for k, i := range kids {
+			if visited[i] {
+				continue
+			}
+			visited[i] = true
+			last := k == len(kids)-1
+			visit(i, indent, last)
+			if last {
+				walk(components[i].ID, indent+"   ")
+			} else {
+				walk(components[i].ID, indent+"│  ")
+			}
+		}
+	}
+	walk("", "")
+
+	// Sessions caught in a parent cycle are shown at the top level
+	for i := range components {
+		if !visited[i] {
+			visited[i] = true
+			visit(i, "", i == len(components)-1)
+		}
+	}
+}
+
+// orderAsTree returns components in depth-first spawn tree order
+func orderAsTree(components []Component) []Component {
+	ordered := make([]Component, 0, len(components))
+	walkTree(components, func(i int, indent string, last bool) {
+		ordered = append(ordered, components[i])
+	})
+	return ordered
 }
 
 // sortComponentsByHierarchy sorts components by persona hierarchy
@@ -454,7 +603,8 @@ func (m *OrgChartModel) getRoleDescription(personaType session.SessionType) stri
 	case session.SessionTypeIntern:
 		return "Support"
 	default:
-		return "Unknown"
+		// DevOps and custom personas are shown by type
+		return string(personaType)
 	}
 }
 
@@ -515,17 +665,76 @@ func (m OrgChartModel) View() string {
 		b.WriteString(m.renderDetails())
 	}
 
+	// Render spawn requests waiting for approval or capacity
+	b.WriteString(m.renderPendingRequests())
+	b.WriteString(m.renderSpawnQueue())
+
 	// Render cost estimate section
 	b.WriteString(m.renderCostEstimate())
 
 	// Footer
 	b.WriteString("\n")
-	instructions := "↑↓/jk: navigate | d: details | a: attach | K: kill session | esc/b: back | q: quit"
+	if m.composing && m.selectedIndex < len(m.components) {
+		prompt := fmt.Sprintf("Message to %s: %s█", m.components[m.selectedIndex].Name, m.composeBuffer)
+		b.WriteString(statusMessageStyle.Render(prompt))
+		b.WriteString("\n")
+		b.WriteString(footerStyle.Render("enter: send | esc: cancel"))
+		return b.String()
+	}
+	instructions := "↑↓/jk: navigate | d: details | a: attach | m: message | K: kill session | esc/b: back | q: quit"
 	b.WriteString(footerStyle.Render(instructions))
 
 	return b.String()
 }
 
+// renderPendingRequests lists spawn requests waiting for a human decision
+func (m OrgChartModel) renderPendingRequests() string {
+	if m.sessionManager == nil {
+		return ""
+	}
+	requests, err := m.sessionManager.LoadSpawnRequests()
+	if err != nil || len(requests) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(liveOutputHeaderStyle.Render(fmt.Sprintf("📥 Spawn Requests (%d)", len(requests))))
+	b.WriteString("\n")
+	for _, req := range requests {
+		line := fmt.Sprintf("  %s [%s]", req.ID, req.Status)
+		if req.Requester != "" {
+			line += " from " + req.Requester
+		}
+		if req.Summary != "" {
+			line += ": " + req.Summary
+		}
+		b.WriteString(listItemStyle.Render(line))
+		b.WriteString("\n")
+	}
+	b.WriteString(dividerStyle.Render("  wildwest requests approve|deny <id>"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderSpawnQueue lists spawn requests waiting for capacity
+func (m OrgChartModel) renderSpawnQueue() string {
+	if len(m.spawnQueue) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(liveOutputHeaderStyle.Render(fmt.Sprintf("⏳ Spawn Queue (%d)", len(m.spawnQueue))))
+	b.WriteString("\n")
+	for i, q := range m.spawnQueue {
+		line := fmt.Sprintf("  %d. %s (%s), waiting %s", i+1, q.ID, q.Reason, time.Since(q.QueuedAt).Round(time.Second))
+		b.WriteString(listItemStyle.Render(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m OrgChartModel) renderList() string {
 	var b strings.Builder
 
@@ -539,18 +748,24 @@ func (m OrgChartModel) renderList() string {
 		return b.String()
 	}
 
-	for i, comp := range m.components {
-		statusMarker := m.getStatusMarker(comp.Status)
-
-		// Tree structure prefix
-		var prefix, continuation string
-		if i == len(m.components)-1 {
-			prefix = "└─"
-			continuation = "  "
+	// Tree structure prefixes: sessions are nested under their requester
+	prefixes := make([]string, len(m.components))
+	continuations := make([]string, len(m.components))
+	indents := make([]string, len(m.components))
+	walkTree(m.components, func(i int, indent string, last bool) {
+		indents[i] = indent
+		if last {
+			prefixes[i] = indent + "└─"
+			continuations[i] = indent + "  "
 		} else {
-			prefix = "├─"
-			continuation = "│ "
+			prefixes[i] = indent + "├─"
+			continuations[i] = indent + "│ "
 		}
+	})
+
+	for i, comp := range m.components {
+		statusMarker := m.getStatusMarker(comp.Status)
+		prefix, continuation := prefixes[i], continuations[i]
 
 		// Main item line with tmux spawn indicator
 		var line string
@@ -560,6 +775,12 @@ func (m OrgChartModel) renderList() string {
 		} else {
 			tmuxIndicator = " ⏳"  // Not spawned yet
 		}
+		if comp.Branch != "" {
+			tmuxIndicator += " " + MergeStatusIcon(comp.MergeStatus)
+		}
+		if comp.Overdue > 0 {
+			tmuxIndicator += " ⏰"
+		}
 
 		if i == m.selectedIndex {
 			line = fmt.Sprintf("%s %s  %s (%s)%s", prefix, statusMarker, comp.Name, comp.Role, tmuxIndicator)
@@ -597,7 +818,7 @@ func (m OrgChartModel) renderList() string {
 
 		// Vertical separator between items (except last)
 		if i < len(m.components)-1 {
-			b.WriteString(dividerStyle.Render("│"))
+			b.WriteString(dividerStyle.Render(indents[i+1] + "│"))
 			b.WriteString("\n")
 		}
 	}
@@ -637,11 +858,19 @@ func (m OrgChartModel) killSession() tea.Cmd {
 			return tea.Quit()
 		}
 
+		// Look up which backend runs each spawned worker
+		backends := make(map[string]string)
+		if sessions, err := m.sessionManager.GetAllSessions(); err == nil {
+			for _, sess := range sessions {
+				backends[sess.TmuxSession] = sess.Backend
+			}
+		}
+
 		killed := 0
 		// Kill all spawned agent sessions
-		for _, tmuxSession := range state.SpawnedSessions {
-			cmd := exec.Command("tmux", "kill-session", "-t", tmuxSession)
-			if cmd.Run() == nil {
+		for _, processName := range state.SpawnedSessions {
+			b := backend.ForSession(backends[processName], m.workspacePath)
+			if b.Kill(processName) == nil {
 				killed++
 			}
 		}
@@ -661,6 +890,13 @@ func (m OrgChartModel) killSession() tea.Cmd {
 	}
 }
 
+// refreshBurnRate re-reads the cost history; rendering uses the cached rate
+func (m *OrgChartModel) refreshBurnRate() {
+	if history, err := LoadCostHistory(m.workspacePath); err == nil {
+		m.burnRate = history.BurnRate(DefaultBurnRateWindow, time.Now())
+	}
+}
+
 func (m OrgChartModel) renderCostEstimate() string {
 	var b strings.Builder
 
@@ -670,26 +906,35 @@ func (m OrgChartModel) renderCostEstimate() string {
 	// Get total cost from session manager
 	totalCost, usageMap, err := m.sessionManager.GetTotalTeamCost()
 	if err != nil || len(usageMap) == 0 {
-		b.WriteString(logsHeaderStyle.Render("💰 Cost Estimate: $0.00 (no usage data yet)"))
+		b.WriteString(logsHeaderStyle.Render("💰 Cost: $0.00 (no usage data yet)"))
 		b.WriteString("\n")
 		return b.String()
 	}
 
 	// Calculate total tokens
-	var totalInputTokens, totalOutputTokens int64
+	var totalInputTokens, totalOutputTokens, totalTokens int64
+	label := "Cost"
 	for _, usage := range usageMap {
 		totalInputTokens += usage.InputTokens
 		totalOutputTokens += usage.OutputTokens
+		totalTokens += usage.TotalTokens
+		if usage.Scraped {
+			label = "Cost Estimate"
+		}
 	}
-	totalTokens := totalInputTokens + totalOutputTokens
 
 	// Format the cost summary
-	costLine := fmt.Sprintf("💰 Cost Estimate: %s | Tokens: %s in, %s out (%s total)",
+	costLine := fmt.Sprintf("💰 %s: %s | Tokens: %s in, %s out (%s total)",
+		label,
 		session.FormatCost(totalCost),
 		session.FormatTokens(totalInputTokens),
 		session.FormatTokens(totalOutputTokens),
 		session.FormatTokens(totalTokens))
 
+	if m.burnRate > 0 {
+		costLine += fmt.Sprintf(" | Burn: %s", FormatBurnRate(m.burnRate))
+	}
+
 	b.WriteString(logsHeaderStyle.Render(costLine))
 	b.WriteString("\n")
 
@@ -758,21 +1003,17 @@ func (m *OrgChartModel) generateSpawnMessage(newSessions, oldSessions []*session
 	return ""
 }
 
-// captureTmuxOutput captures the last N lines from a tmux session
-func (m OrgChartModel) captureTmuxOutput(tmuxSession string, lines int) string {
-	cmd := exec.Command("tmux", "capture-pane",
-		"-t", tmuxSession,
-		"-p",
-		"-S", fmt.Sprintf("-%d", lines))
-
-	output, err := cmd.CombinedOutput()
+// captureOutput captures the last N lines from a component's worker process
+func (m OrgChartModel) captureOutput(comp Component, lines int) string {
+	b := backend.ForSession(comp.Backend, m.workspacePath)
+	output, err := b.CaptureOutput(comp.TmuxSession, lines)
 	if err != nil {
 		// Session might not exist or no output yet
 		return ""
 	}
 
 	// Trim and return the output
-	result := strings.TrimSpace(string(output))
+	result := strings.TrimSpace(output)
 	if result == "" {
 		return ""
 	}
@@ -812,13 +1053,48 @@ func (m OrgChartModel) renderDetails() string {
 	detailsBuilder.WriteString(fmt.Sprintf("%s\n\n", comp.Name))
 	detailsBuilder.WriteString(fmt.Sprintf("Role:   %s\n", comp.Role))
 	detailsBuilder.WriteString(fmt.Sprintf("Status: %s %s\n", statusMarker, statusLabel))
+	if tasks, err := m.sessionManager.LoadTasks(comp.ID); err == nil && len(tasks.Tasks) > 0 {
+		detailsBuilder.WriteString(fmt.Sprintf("Tasks:  %d/%d completed, %d in progress\n",
+			tasks.Count(session.TaskStatusCompleted), len(tasks.Tasks), tasks.Count(session.TaskStatusInProgress)))
+	}
+	if comp.ParentID != "" {
+		requester := comp.ParentID
+		for _, c := range m.components {
+			if c.ID == comp.ParentID {
+				requester = fmt.Sprintf("%s (%s)", c.Name, c.ID)
+				break
+			}
+		}
+		detailsBuilder.WriteString(fmt.Sprintf("Requested by: %s\n", requester))
+	}
+	if comp.Priority != session.PriorityNormal {
+		detailsBuilder.WriteString(fmt.Sprintf("Priority: %s\n", session.PriorityName(comp.Priority)))
+	}
+	if !comp.Deadline.IsZero() {
+		detailsBuilder.WriteString(fmt.Sprintf("Due:    %s (%s)\n", comp.Deadline.Format(session.TaskTimeFormat), session.FormatDue(comp.Deadline, time.Now())))
+	}
+	if comp.Overdue > 0 {
+		detailsBuilder.WriteString(fmt.Sprintf("⏰ Overdue: %d\n", comp.Overdue))
+	}
+
+	if comp.Branch != "" {
+		mergeStatus := comp.MergeStatus
+		if mergeStatus == "" {
+			mergeStatus = "pending"
+		}
+		detailsBuilder.WriteString(fmt.Sprintf("Branch: %s\n", comp.Branch))
+		detailsBuilder.WriteString(fmt.Sprintf("Merge:  %s %s\n", MergeStatusIcon(comp.MergeStatus), mergeStatus))
+		if comp.MergeDetail != "" {
+			detailsBuilder.WriteString(fmt.Sprintf("        %s\n", comp.MergeDetail))
+		}
+	}
 
 	detailsBuilder.WriteString(fmt.Sprintf("\nCurrent Activity:\n%s\n", comp.StatusMessage))
 	detailsBuilder.WriteString(fmt.Sprintf("\nDescription:\n%s", comp.Description))
 
-	// Add live output from tmux session (last 10 lines)
+	// Add live output from worker process (last 10 lines)
 	if comp.TmuxSpawned && comp.TmuxSession != "" {
-		liveOutput := m.captureTmuxOutput(comp.TmuxSession, 10)
+		liveOutput := m.captureOutput(comp, 10)
 		if liveOutput != "" {
 			var liveBuilder strings.Builder
 			liveBuilder.WriteString(liveOutputHeaderStyle.Render("Live Output (last 10 lines)"))
@@ -834,17 +1110,22 @@ func (m OrgChartModel) renderDetails() string {
 
 // RunStaticTUI starts the static org chart TUI with orchestrator
 func RunStaticTUI() error {
-	return RunStaticTUIWithWorkspace(".ww-db", "")
+	return RunStaticTUIWithWorkspace(".ww-db", "", "")
 }
 
-// RunStaticTUIWithWorkspace starts the TUI with a specific workspace
-func RunStaticTUIWithWorkspace(workspacePath, version string) error {
+// RunStaticTUIWithWorkspace starts the TUI with a specific workspace, pricing
+// costs with the config file at configPath (the default config when empty)
+func RunStaticTUIWithWorkspace(workspacePath, configPath, version string) error {
 	// Create session manager directly (no orchestrator needed for read-only TUI)
 	sm, err := session.NewSessionManager(workspacePath)
 	if err != nil {
 		return fmt.Errorf("failed to create session manager: %w", err)
 	}
 
+	if cfg, err := config.LoadConfig(configPath); err == nil {
+		sm.SetPricing(PricingTable(cfg))
+	}
+
 	// Loop to allow returning to TUI after detaching from tmux
 	for {
 		// Load sessions BEFORE starting TUI so they're ready immediately
@@ -858,6 +1139,7 @@ func RunStaticTUIWithWorkspace(workspacePath, version string) error {
 		model.activeSessions = sessions
 		model.updateComponentsFromSessions()
 		model.loadOrchestratorState() // Add orchestrator at top level
+		model.refreshBurnRate()
 		model.initialized = true
 		model.addLog(fmt.Sprintf("Loaded %d sessions from %s", len(sessions), workspacePath))
 		if len(sessions) > 0 {
@@ -873,20 +1155,15 @@ func RunStaticTUIWithWorkspace(workspacePath, version string) error {
 			return err
 		}
 
-		// Check if we need to attach to a tmux session
+		// Check if we need to attach to a worker process
 		if m, ok := finalModel.(OrgChartModel); ok && m.attachToSession != "" {
-			// Clear screen and exec into tmux
-			cmd := exec.Command("bash", "-c", fmt.Sprintf("clear && tmux attach -t %s", m.attachToSession))
-			cmd.Stdin = os.Stdin
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			err := cmd.Run()
-			if err != nil {
-				// If tmux command fails, show error and return to TUI
-				fmt.Printf("Error attaching to tmux: %v\nPress Enter to return to TUI...", err)
+			b := backend.ForSession(m.attachBackend, workspacePath)
+			if err := b.Attach(m.attachToSession); err != nil {
+				// If attach fails, show error and return to TUI
+				fmt.Printf("Error attaching to %s: %v\nPress Enter to return to TUI...", m.attachToSession, err)
 				fmt.Scanln()
 			}
-			// After detaching from tmux, loop back to TUI
+			// After detaching, loop back to TUI
 			continue
 		}
 
@@ -0,0 +1,249 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/tarzzz/wildwest/pkg/persona"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// WorkspaceEventType identifies what changed in the workspace
+type WorkspaceEventType string
+
+const (
+	EventRequestCreated       WorkspaceEventType = "request-created"       // A *-request-* directory received its instructions
+	EventTasksChanged         WorkspaceEventType = "tasks-changed"         // A persona's tasks.md was written
+	EventInstructionsAppended WorkspaceEventType = "instructions-appended" // A persona's instructions.md was written
+	EventSessionChanged       WorkspaceEventType = "session-changed"       // A persona's session.json was written
+	EventWorkerExited         WorkspaceEventType = "worker-exited"         // A worker script recorded its exit status
+	EventUsageRecorded        WorkspaceEventType = "usage-recorded"        // A worker appended to its usage log
+	EventBoardChanged         WorkspaceEventType = "board-changed"         // The shared task board was written
+	EventRequestsDecided      WorkspaceEventType = "requests-decided"      // The spawn approval queue was written
+	EventCheckFinished        WorkspaceEventType = "check-finished"        // A background gate or merge check finished (sent by the orchestrator)
+)
+
+// WorkspaceEvent is a typed change notification from the workspace watcher
+type WorkspaceEvent struct {
+	Type WorkspaceEventType
+	Dir  string // Session or request directory name
+	Path string // File that changed
+}
+
+// watchDebounce is how long the watcher waits for a burst of writes to settle
+const watchDebounce = 300 * time.Millisecond
+
+// watchMaxDelay bounds how long a steady stream of writes can hold events back
+const watchMaxDelay = 2 * time.Second
+
+// Watcher turns filesystem notifications under a workspace into WorkspaceEvents.
+// It watches the workspace root and every session and request directory in it.
+type Watcher struct {
+	workspacePath string
+	fsw           *fsnotify.Watcher
+	events        chan WorkspaceEvent
+	errors        chan error
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+// NewWatcher starts watching a workspace
+func NewWatcher(workspacePath string) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		workspacePath: workspacePath,
+		fsw:           fsw,
+		events:        make(chan WorkspaceEvent, 64),
+		errors:        make(chan error, 8),
+		done:          make(chan struct{}),
+	}
+
+	if err := fsw.Add(workspacePath); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	// The shared directory is only watched for the task board, and the
+	// orchestrator directory for the spawn approval queue
+	fsw.Add(filepath.Join(workspacePath, "shared"))
+	fsw.Add(filepath.Join(workspacePath, "orchestrator"))
+
+	entries, err := os.ReadDir(workspacePath)
+	if err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && w.watchable(entry.Name()) {
+			fsw.Add(filepath.Join(workspacePath, entry.Name()))
+		}
+	}
+
+	go w.loop()
+	return w, nil
+}
+
+// Events returns the channel of debounced workspace events
+func (w *Watcher) Events() <-chan WorkspaceEvent {
+	return w.events
+}
+
+// Errors returns the channel of watcher errors
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops the watcher
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.fsw.Close()
+	})
+	return err
+}
+
+// watchable reports whether a workspace subdirectory should be watched
+func (w *Watcher) watchable(name string) bool {
+	if name == "shared" || name == "orchestrator" || name == worktreesDir || strings.HasPrefix(name, ".") {
+		return false
+	}
+	return !strings.HasSuffix(name, "-completed") && !strings.HasSuffix(name, "-archived")
+}
+
+// loop collects raw notifications and emits each distinct event once no
+// relevant change has been seen for watchDebounce, or watchMaxDelay after the
+// first pending event. Notifications translate ignores (worker logs and the
+// like) do not delay events.
+func (w *Watcher) loop() {
+	defer close(w.events)
+
+	pending := make(map[WorkspaceEvent]bool)
+	var order []WorkspaceEvent
+	var firstPending time.Time
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case ev, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			event, ok := w.translate(ev)
+			if !ok {
+				continue
+			}
+			if len(order) == 0 {
+				firstPending = time.Now()
+			}
+			if !pending[event] {
+				pending[event] = true
+				order = append(order, event)
+			}
+			wait := watchDebounce
+			if remaining := time.Until(firstPending.Add(watchMaxDelay)); remaining < wait {
+				wait = remaining
+			}
+			timer.Reset(wait)
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case w.errors <- err:
+			default:
+			}
+
+		case <-timer.C:
+			for _, event := range order {
+				select {
+				case w.events <- event:
+				case <-w.done:
+					return
+				}
+			}
+			pending = make(map[WorkspaceEvent]bool)
+			order = nil
+		}
+	}
+}
+
+// translate maps a raw notification to a typed event
+func (w *Watcher) translate(ev fsnotify.Event) (WorkspaceEvent, bool) {
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Write) {
+		return WorkspaceEvent{}, false
+	}
+
+	rel, err := filepath.Rel(w.workspacePath, ev.Name)
+	if err != nil {
+		return WorkspaceEvent{}, false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+
+	// New directory in the workspace root: start watching it
+	if len(parts) == 1 {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() && w.watchable(parts[0]) {
+			w.fsw.Add(ev.Name)
+		}
+		return WorkspaceEvent{}, false
+	}
+	if len(parts) != 2 {
+		return WorkspaceEvent{}, false
+	}
+
+	dir, file := parts[0], parts[1]
+	event := WorkspaceEvent{Dir: dir, Path: ev.Name}
+
+	if dir == "shared" {
+		if file != "tasks.md" {
+			return WorkspaceEvent{}, false
+		}
+		event.Type = EventBoardChanged
+		return event, true
+	}
+
+	if dir == "orchestrator" {
+		if rel != filepath.FromSlash(session.SpawnRequestsFile) {
+			return WorkspaceEvent{}, false
+		}
+		event.Type = EventRequestsDecided
+		return event, true
+	}
+
+	if strings.Contains(dir, persona.RequestInfix) {
+		if file != "instructions.md" {
+			return WorkspaceEvent{}, false
+		}
+		event.Type = EventRequestCreated
+		return event, true
+	}
+
+	switch file {
+	case "tasks.md":
+		event.Type = EventTasksChanged
+	case "instructions.md":
+		event.Type = EventInstructionsAppended
+	case "session.json":
+		event.Type = EventSessionChanged
+	case exitStatusFile:
+		event.Type = EventWorkerExited
+	case session.UsageLogFile:
+		event.Type = EventUsageRecorded
+	default:
+		return WorkspaceEvent{}, false
+	}
+	return event, true
+}
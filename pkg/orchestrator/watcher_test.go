@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startWatcher watches a new workspace containing the given directories
+func startWatcher(t *testing.T, dirs ...string) (*Watcher, string) {
+	t.Helper()
+	workspace := t.TempDir()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(workspace, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, err := NewWatcher(workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return w, workspace
+}
+
+// writeFile writes content to a file in the workspace
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// nextEvent waits for the next workspace event, failing after timeout
+func nextEvent(t *testing.T, w *Watcher, timeout time.Duration) WorkspaceEvent {
+	t.Helper()
+	select {
+	case event := <-w.Events():
+		return event
+	case <-time.After(timeout):
+		t.Fatalf("no workspace event within %v", timeout)
+		return WorkspaceEvent{}
+	}
+}
+
+// keepWriting writes to path every 20ms until the test ends
+func keepWriting(t *testing.T, path string) {
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(20 * time.Millisecond):
+				os.WriteFile(path, []byte(time.Now().String()), 0644)
+			}
+		}
+	}()
+}
+
+func TestWatcherTranslatesWorkspaceFiles(t *testing.T) {
+	w, workspace := startWatcher(t, "software-engineer-1", "intern-request-docs", "shared")
+
+	writeFile(t, filepath.Join(workspace, "software-engineer-1", "tasks.md"), "# Tasks\n")
+	writeFile(t, filepath.Join(workspace, "software-engineer-1", "notes.txt"), "ignored")
+	writeFile(t, filepath.Join(workspace, "intern-request-docs", "instructions.md"), "Write docs")
+	writeFile(t, filepath.Join(workspace, "shared", "tasks.md"), "# Board\n")
+
+	want := map[WorkspaceEvent]bool{
+		{Type: EventTasksChanged, Dir: "software-engineer-1", Path: filepath.Join(workspace, "software-engineer-1", "tasks.md")}:          true,
+		{Type: EventRequestCreated, Dir: "intern-request-docs", Path: filepath.Join(workspace, "intern-request-docs", "instructions.md")}: true,
+		{Type: EventBoardChanged, Dir: "shared", Path: filepath.Join(workspace, "shared", "tasks.md")}:                                    true,
+	}
+	for len(want) > 0 {
+		event := nextEvent(t, w, 5*time.Second)
+		if !want[event] {
+			t.Fatalf("unexpected event %+v", event)
+		}
+		delete(want, event)
+	}
+}
+
+func TestWatcherIgnoredWritesDoNotDelayEvents(t *testing.T) {
+	w, workspace := startWatcher(t, "software-engineer-1")
+
+	// A chatty worker log is not a workspace event and must not hold others back
+	keepWriting(t, filepath.Join(workspace, "software-engineer-1", "worker.log"))
+	start := time.Now()
+	writeFile(t, filepath.Join(workspace, "software-engineer-1", "tasks.md"), "# Tasks\n")
+
+	if event := nextEvent(t, w, watchMaxDelay); event.Type != EventTasksChanged {
+		t.Errorf("event = %+v", event)
+	}
+	if elapsed := time.Since(start); elapsed > watchDebounce+time.Second {
+		t.Errorf("event took %v with only ignored writes going on", elapsed)
+	}
+}
+
+func TestWatcherFlushesAfterMaxDelay(t *testing.T) {
+	w, workspace := startWatcher(t, "software-engineer-1")
+
+	// Writes that never settle still produce an event after watchMaxDelay
+	start := time.Now()
+	keepWriting(t, filepath.Join(workspace, "software-engineer-1", "tasks.md"))
+
+	if event := nextEvent(t, w, watchMaxDelay+2*time.Second); event.Type != EventTasksChanged {
+		t.Errorf("event = %+v", event)
+	}
+	if elapsed := time.Since(start); elapsed < watchMaxDelay/2 {
+		t.Errorf("event after %v, before the writes could have settled", elapsed)
+	}
+}
@@ -0,0 +1,105 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/tarzzz/wildwest/pkg/git"
+	"github.com/tarzzz/wildwest/pkg/session"
+)
+
+// worktreesDir holds the git worktrees of isolated sessions, outside the
+// persona directories so archiving a session does not move its worktree
+const worktreesDir = "worktrees"
+
+// BranchPrefix is prepended to a session ID to name its worktree branch
+const BranchPrefix = "wildwest/"
+
+// isolatedPersonas are the persona types given their own worktree
+var isolatedPersonas = []session.SessionType{
+	session.SessionTypeSoftwareEngineer,
+	session.SessionTypeIntern,
+}
+
+// isIsolatedPersona reports whether a persona type gets its own worktree
+func isIsolatedPersona(personaType session.SessionType) bool {
+	for _, t := range isolatedPersonas {
+		if t == personaType {
+			return true
+		}
+	}
+	return false
+}
+
+// worktreeIsolation reports whether the workspace was started with --isolate=worktree
+func (o *Orchestrator) worktreeIsolation() bool {
+	workspace, err := o.sm.GetWorkspace()
+	return err == nil && workspace.Isolation == session.IsolationWorktree
+}
+
+// prepareWorktree creates the git worktree and branch an isolated session runs
+// in and records them in session.json. A restarted session reuses its worktree.
+func (o *Orchestrator) prepareWorktree(sess *session.Session) error {
+	if !isIsolatedPersona(sess.PersonaType) || !o.worktreeIsolation() {
+		return nil
+	}
+	if sess.WorktreePath != "" && git.IsWorktree(sess.WorktreePath) {
+		return nil
+	}
+
+	repo, err := git.FindRepo(o.workspacePath)
+	if err != nil {
+		return fmt.Errorf("failed to find git repository: %w", err)
+	}
+
+	branch := sess.Branch
+	if branch == "" {
+		branch = BranchPrefix + sess.ID
+	}
+	absWorkspace, _ := filepath.Abs(o.workspacePath)
+	path := filepath.Join(absWorkspace, worktreesDir, sess.ID)
+
+	if err := repo.AddWorktree(path, branch, ""); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	sess.Branch = branch
+	sess.WorktreePath = path
+	if err := o.sm.UpdateSession(sess.ID, func(s *session.Session) {
+		s.Branch = branch
+		s.WorktreePath = path
+	}); err != nil {
+		return fmt.Errorf("failed to record worktree: %w", err)
+	}
+
+	o.log("   🌿 Worktree: %s (branch %s)\n", path, branch)
+	return nil
+}
+
+// releaseWorktree removes a completed session's worktree but keeps its branch
+// for review. A worktree with uncommitted changes is left in place.
+func (o *Orchestrator) releaseWorktree(sess *session.Session) {
+	if sess.WorktreePath == "" || !git.IsWorktree(sess.WorktreePath) {
+		return
+	}
+
+	dirty, err := git.HasChanges(sess.WorktreePath)
+	if err != nil {
+		o.log("   ⚠️  Failed to check worktree: %v\n", err)
+		return
+	}
+	if dirty {
+		o.log("   ⚠️  Worktree has uncommitted changes, keeping: %s\n", sess.WorktreePath)
+		return
+	}
+
+	repo, err := git.FindRepo(o.workspacePath)
+	if err == nil {
+		err = repo.RemoveWorktree(sess.WorktreePath, false)
+	}
+	if err != nil {
+		o.log("   ⚠️  Failed to remove worktree: %v\n", err)
+		return
+	}
+	o.log("   🌿 Branch kept for review: %s\n", sess.Branch)
+}
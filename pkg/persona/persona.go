@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// Persona represents a role-based configuration for Claude
+// Persona represents a role-based configuration for Claude. Instructions and
+// Scaffolding are text/templates rendered with a PromptContext.
 type Persona struct {
 	Name         string   `yaml:"name"`
 	Description  string   `yaml:"description"`
@@ -16,11 +19,24 @@ type Persona struct {
 	Capabilities []string `yaml:"capabilities"`
 	Constraints  []string `yaml:"constraints"`
 	Examples     []string `yaml:"examples,omitempty"`
+	Model        string   `yaml:"model,omitempty"`       // Passed to claude --model (alias or full model ID)
+	Extends      string   `yaml:"extends,omitempty"`     // Persona this one builds on
+	Mixins       []string `yaml:"mixins,omitempty"`      // Mixins layered on top, in order
+	Scaffolding  string   `yaml:"scaffolding,omitempty"` // Replaces DefaultScaffolding in the system prompt
 }
 
 // PersonaConfig holds all persona definitions
 type PersonaConfig struct {
 	Personas map[string]Persona `yaml:"personas"`
+	Mixins   map[string]Mixin   `yaml:"mixins,omitempty"`
+}
+
+// Mixin is a reusable fragment of instructions, capabilities and constraints
+// that personas can include
+type Mixin struct {
+	Instructions string   `yaml:"instructions,omitempty"`
+	Capabilities []string `yaml:"capabilities,omitempty"`
+	Constraints  []string `yaml:"constraints,omitempty"`
 }
 
 // DefaultPersonas returns the default persona configurations
@@ -45,16 +61,16 @@ Your role is to:
 ## How Personas Request New Team Members
 
 **Engineering Manager or Solutions Architect** can request Software Engineers by:
-- Creating a directory: .ww-db/software-engineer-request-{name}/
+- Creating a directory: {{.Workspace}}/software-engineer-request-{name}/
 - Creating an initial instructions.md in that directory
 
 **Software Engineers** can request Interns by:
-- Creating a directory: .ww-db/intern-request-{name}/
+- Creating a directory: {{.Workspace}}/intern-request-{name}/
 - Creating an initial instructions.md in that directory
 
 ## Your Responsibilities
 
-1. **Watch for New Directories**: Scan .ww-db/ for *-request-* directories
+1. **Watch for New Directories**: Scan {{.Workspace}}/ for *-request-* directories
 2. **Spawn Claude Instances**: Start actual Claude Code sessions for request directories
 3. **Rename Directories**: Rename from *-request-* to active session ID after spawning
 4. **Monitor Progress**: Check all sessions' tasks.md for completion
@@ -98,22 +114,21 @@ CRITICAL: Do NOT code. Delegate to specialists.
 ## Quick Reference
 
 **Check status:**
-  for dir in .ww-db/*-[0-9]*/; do tail -20 "$dir/tasks.md"; done
+  for dir in {{.Workspace}}/*-[0-9]*/; do tail -20 "$dir/tasks.md"; done
 
 **Assign work (KEEP BRIEF - 2-4 sentences max):**
-  cat >> .ww-db/software-engineer-*/instructions.md <<EOF
-  ## $(date +%Y-%m-%d_%H:%M:%S)
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer - <<EOF
   [Brief task: what to do]
   [Key files if needed]
   EOF
 
 **Request resources:**
-  mkdir .ww-db/{type}-request-{name}
-  cat > .ww-db/{type}-request-{name}/instructions.md <<EOF
+  mkdir {{.Workspace}}/{type}-request-{name}
+  cat > {{.Workspace}}/{type}-request-{name}/instructions.md <<EOF
   [Brief task]
   EOF
 
-Types: solutions-architect-request-*, software-engineer-request-*, qa-request-*, intern-request-*
+Types: {{range $i, $t := .RequestTypes}}{{if $i}}, {{end}}{{$t.Key}}-request-*{{end}}
 
 ## Communication: BE CONCISE
 - Instructions: 2-4 sentences max
@@ -133,7 +148,7 @@ Bad: "Please carefully implement the authentication endpoints considering edge c
 Auto-assign next tasks when agents complete work.
 
 ## CRITICAL: Continuously Monitor for Completion Reports
-- Check instructions.md every 30 seconds for completion reports from agents
+- You are run again whenever a completion report arrives in instructions.md
 - When agents report "COMPLETED", immediately assign them new work
 - Don't wait passively - actively monitor and assign tasks`,
 				Capabilities: []string{
@@ -155,7 +170,7 @@ Auto-assign next tasks when agents complete work.
 					"Should provide clear, detailed requirements to team members",
 					"Should review all major deliverables before completion",
 					"Must ensure alignment with business goals",
-					"Should give instructions via instructions.md to team members",
+					"Should give instructions to team members with wildwest send",
 				},
 			},
 			"software-engineer": {
@@ -168,7 +183,7 @@ Auto-assign next tasks when agents complete work.
 - Make implementation decisions and ask for clarification when needed
 - Write comprehensive tests for your implementations
 - Debug and fix issues
-- Collaborate with other agents via their instructions.md
+- Collaborate with other agents with wildwest send
 - REQUEST additional resources when needed (QA, Support, Architect, etc.)
 
 COLLABORATION: You can communicate with and receive instructions from ANY agent.
@@ -177,34 +192,28 @@ You can also give instructions to ANY agent - no restrictions.
 ## Communicating with Other Agents
 
 Request QA resources from Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## Resource Request from Coder ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  Resource Request
   I've completed the user registration feature and need QA support.
   Please assign a QA Engineer to write integration tests.
   Code location: [path to implementation]
   EOF
 
 Request architecture clarification:
-  cat >> .ww-db/solutions-architect-*/instructions.md <<EOF
-
-  ## Question from Coder ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} solutions-architect --type question - <<EOF
   Need clarification on the authentication flow design.
   Should we use stateless JWT or session-based auth?
   EOF
 
 Delegate minor tasks to Support Agent:
-  cat >> .ww-db/intern-*/instructions.md <<EOF
-
-  ## Task from Coder ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} intern - <<EOF
   Please add unit tests for the validation functions in utils/validators.go
   Follow the existing test patterns in the codebase.
   EOF
 
 Report completion to Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## Status Update from Coder ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  Status Update
   Feature completed: User registration endpoint
   Ready for QA testing and code review.
   EOF
@@ -212,9 +221,8 @@ Report completion to Leader:
 ## IMPORTANT: Report Completion to Leader
 
 When your work is DONE, you MUST report to Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## COMPLETED - Coder Work Done ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  COMPLETED - Coder Work Done
   Task: [describe what was completed]
   Implementation: [describe what was built]
   Location: [file paths]
@@ -237,7 +245,7 @@ When your work is DONE, you MUST report to Leader:
 					"Must follow Solutions Architect's technical specifications",
 					"Should implement according to designed architecture",
 					"Must write tests for all major features",
-					"Should assign minor tasks (tests, linting) to Interns via instructions.md",
+					"Should assign minor tasks (tests, linting) to Interns with wildwest send",
 					"Must review intern's work before marking tasks complete",
 				},
 			},
@@ -260,9 +268,7 @@ You can also give instructions or feedback to ANY agent - no restrictions.
 ## Communicating with Other Agents
 
 Ask for clarification:
-  cat >> .ww-db/software-engineer-*/instructions.md <<EOF
-
-  ## Question from Support ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer --type question - <<EOF
   The test instructions mention "validation functions" but I found
   multiple validator files. Which one should I focus on?
   - utils/validators.go
@@ -270,18 +276,16 @@ Ask for clarification:
   EOF
 
 Report completion:
-  cat >> .ww-db/software-engineer-*/instructions.md <<EOF
-
-  ## Task Completed by Support ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer - <<EOF
+  Task Completed
   Added unit tests for validation functions.
   Coverage increased from 60% to 95%.
   All tests passing.
   EOF
 
 Provide feedback to anyone:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## Observation from Support ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  Observation
   I noticed the codebase has inconsistent formatting.
   Should I create a task to run gofmt across all files?
   EOF
@@ -289,9 +293,8 @@ Provide feedback to anyone:
 ## IMPORTANT: Report Completion to Leader
 
 When your work is DONE, you MUST report to Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## COMPLETED - Support Work Done ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  COMPLETED - Support Work Done
   Task: [describe what was completed]
   Changes Made: [list files modified]
   Tests Added: [if applicable]
@@ -328,7 +331,7 @@ Design architecture → Create specs → Guide implementation
 
 ## Quick Output Format
 
-**Architecture Doc (save to .ww-db/shared/design-{topic}.md):**
+**Architecture Doc (save to {{.Workspace}}/shared/design-{topic}.md):**
 ## System Design: {Topic}
 
 ### Architecture
@@ -350,24 +353,22 @@ Design architecture → Create specs → Guide implementation
 Write brief, actionable specs. Use bullet points. No lengthy prose.
 
 **Assign to coders:**
-  cat >> .ww-db/software-engineer-*/instructions.md <<EOF
-  ## $(date +%Y-%m-%d_%H:%M:%S)
-  Implement per .ww-db/shared/design-{topic}.md
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer - <<EOF
+  Implement per {{.Workspace}}/shared/design-{topic}.md
   Focus on: [specific components]
   EOF
 
 **Request resources from Leader:**
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-  ## $(date +%Y-%m-%d_%H:%M:%S)
-  Design complete: .ww-db/shared/design-{topic}.md
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  Design complete: {{.Workspace}}/shared/design-{topic}.md
   Need {N} coders for implementation.
   EOF
 
 **Report completion:**
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-  ## COMPLETED - $(date +%Y-%m-%d_%H:%M:%S)
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  COMPLETED
   Design: {topic}
-  Location: .ww-db/shared/design-{topic}.md
+  Location: {{.Workspace}}/shared/design-{topic}.md
   Ready for implementation.
   EOF
 
@@ -386,7 +387,7 @@ Work fast. Focus on key decisions. Skip obvious details.`,
 					"Must read and follow Engineering Manager's requirements",
 					"Should create visual diagrams for system design",
 					"Must provide detailed technical specs to Software Engineers",
-					"Should write instructions to Software Engineers via their instructions.md",
+					"Should send instructions to Software Engineers with wildwest send",
 					"Must document all architectural decisions",
 				},
 			},
@@ -401,7 +402,7 @@ Work fast. Focus on key decisions. Skip obvious details.`,
 - Execute test suites and report results
 - Identify bugs and edge cases
 - Document test coverage and results
-- Report test results back via the requester's instructions.md
+- Report test results back to the requester with wildwest send
 - Update your tasks.md with testing progress
 - Provide quality feedback to any agent
 
@@ -411,9 +412,8 @@ You can also give instructions, feedback, or test results to ANY agent - no rest
 ## Communicating with Other Agents
 
 Report test results to Coder:
-  cat >> .ww-db/software-engineer-*/instructions.md <<EOF
-
-  ## Test Results from QA ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer - <<EOF
+  Test Results
   Tested: User registration endpoint
   Results: 3 tests passed, 2 failed
   Failed tests:
@@ -423,18 +423,15 @@ Report test results to Coder:
   EOF
 
 Report bugs to Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## Critical Bug Report from QA ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  Critical Bug Report
   Found security issue in authentication flow.
   Users can bypass email verification.
   Requires immediate attention.
   EOF
 
 Request Support for test maintenance:
-  cat >> .ww-db/intern-*/instructions.md <<EOF
-
-  ## Task from QA ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} intern - <<EOF
   Please update the test fixtures to match new database schema.
   See: tests/fixtures/users.json
   EOF
@@ -442,9 +439,8 @@ Request Support for test maintenance:
 ## IMPORTANT: Report Completion to Leader
 
 When your testing is DONE, you MUST report to Leader:
-  cat >> .ww-db/engineering-manager-*/instructions.md <<EOF
-
-  ## COMPLETED - QA Work Done ($(date +%Y-%m-%d_%H:%M:%S))
+  wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager - <<EOF
+  COMPLETED - QA Work Done
   Task: [describe what was tested]
   Test Results: [summary of results]
   Coverage: [test coverage percentage]
@@ -474,7 +470,7 @@ When your testing is DONE, you MUST report to Leader:
 					"Should follow testing best practices (AAA pattern, isolation, etc.)",
 					"Must update tasks.md with testing progress",
 					"Should report bugs clearly with reproduction steps",
-					"Must write to requester's instructions.md with results",
+					"Must send results to the requester with wildwest send",
 					"Should NOT fix bugs directly (report to requester instead)",
 				},
 			},
@@ -489,47 +485,187 @@ When your testing is DONE, you MUST report to Leader:
 	}
 }
 
-// LoadPersonas loads persona configuration from file
+// LoadPersonas loads the default personas and merges persona files over them:
+// the given path, or else ~/.claude-personas.yaml and then
+// .claude-personas.yaml in the current directory. A persona or mixin in a file
+// replaces the one with the same key; all others are kept. Inheritance and
+// mixins are resolved afterwards.
 func LoadPersonas(path string) (*PersonaConfig, error) {
-	if path == "" {
-		// Try default location
+	var paths []string
+	if path != "" {
+		paths = []string{path}
+	} else {
 		home, err := os.UserHomeDir()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get home directory: %w", err)
 		}
+		for _, dir := range []string{home, "."} {
+			for _, name := range []string{".claude-personas.yaml", ".claude-personas.yml"} {
+				if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+					paths = append(paths, filepath.Join(dir, name))
+					break
+				}
+			}
+		}
+	}
+
+	defaults := DefaultPersonas()
+	cfg := &PersonaConfig{Personas: make(map[string]Persona), Mixins: make(map[string]Mixin)}
+	for key, p := range defaults.Personas {
+		cfg.Personas[key] = p
+	}
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read personas file: %w", err)
+		}
 
-		possiblePaths := []string{
-			filepath.Join(home, ".claude-personas.yaml"),
-			filepath.Join(home, ".claude-personas.yml"),
-			".claude-personas.yaml",
-			".claude-personas.yml",
+		var file PersonaConfig
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse personas file %s: %w", p, err)
 		}
+		for key, persona := range file.Personas {
+			cfg.Personas[key] = persona
+		}
+		for key, mixin := range file.Mixins {
+			cfg.Mixins[key] = mixin
+		}
+	}
 
-		for _, p := range possiblePaths {
-			if _, err := os.Stat(p); err == nil {
-				path = p
-				break
+	if err := cfg.resolve(defaults.Personas); err != nil {
+		return nil, err
+	}
+	for key, p := range cfg.Personas {
+		if err := p.validateTemplates(key); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// resolve applies extends and mixins to every persona. A persona that extends
+// its own key builds on the built-in persona of that name.
+func (pc *PersonaConfig) resolve(builtin map[string]Persona) error {
+	resolved := make(map[string]Persona, len(pc.Personas))
+
+	var resolveKey func(key string, chain []string) (Persona, error)
+	resolveKey = func(key string, chain []string) (Persona, error) {
+		if p, ok := resolved[key]; ok {
+			return p, nil
+		}
+		for _, k := range chain {
+			if k == key {
+				return Persona{}, fmt.Errorf("persona inheritance cycle: %s", strings.Join(append(chain, key), " -> "))
+			}
+		}
+		chain = append(chain, key)
+
+		p := pc.Personas[key]
+		result := p
+		if p.Extends != "" {
+			var base Persona
+			if p.Extends == key {
+				b, ok := builtin[key]
+				if !ok {
+					return Persona{}, fmt.Errorf("persona '%s' extends itself but is not a built-in persona", key)
+				}
+				base = b
+			} else {
+				if _, ok := pc.Personas[p.Extends]; !ok {
+					return Persona{}, fmt.Errorf("persona '%s' extends unknown persona '%s'", key, p.Extends)
+				}
+				b, err := resolveKey(p.Extends, chain)
+				if err != nil {
+					return Persona{}, err
+				}
+				base = b
 			}
+			result = base.extend(p)
 		}
+
+		for _, name := range p.Mixins {
+			mixin, ok := pc.Mixins[name]
+			if !ok {
+				return Persona{}, fmt.Errorf("persona '%s' uses unknown mixin '%s'", key, name)
+			}
+			result = result.mix(mixin)
+		}
+
+		resolved[key] = result
+		return result, nil
 	}
 
-	// If no config file found, return defaults
-	if path == "" {
-		defaults := DefaultPersonas()
-		return &defaults, nil
+	for key := range pc.Personas {
+		if _, err := resolveKey(key, nil); err != nil {
+			return err
+		}
 	}
+	pc.Personas = resolved
+	return nil
+}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read personas file: %w", err)
+// extend returns base with child layered on top: fields the child sets win,
+// instructions are appended and lists are merged
+func (base Persona) extend(child Persona) Persona {
+	result := base
+	if child.Name != "" {
+		result.Name = child.Name
+	}
+	if child.Description != "" {
+		result.Description = child.Description
+	}
+	if child.Model != "" {
+		result.Model = child.Model
+	}
+	if child.Scaffolding != "" {
+		result.Scaffolding = child.Scaffolding
 	}
+	result.Instructions = joinInstructions(base.Instructions, child.Instructions)
+	result.Capabilities = mergeList(base.Capabilities, child.Capabilities)
+	result.Constraints = mergeList(base.Constraints, child.Constraints)
+	result.Examples = mergeList(base.Examples, child.Examples)
+	result.Extends = child.Extends
+	result.Mixins = child.Mixins
+	return result
+}
+
+// mix returns the persona with a mixin's fragments added
+func (p Persona) mix(m Mixin) Persona {
+	p.Instructions = joinInstructions(p.Instructions, m.Instructions)
+	p.Capabilities = mergeList(p.Capabilities, m.Capabilities)
+	p.Constraints = mergeList(p.Constraints, m.Constraints)
+	return p
+}
 
-	var cfg PersonaConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse personas file: %w", err)
+// joinInstructions layers an instruction fragment below existing instructions
+func joinInstructions(base, fragment string) string {
+	base, fragment = strings.TrimRight(base, "\n"), strings.TrimSpace(fragment)
+	switch {
+	case fragment == "":
+		return base
+	case base == "":
+		return fragment
 	}
+	return base + "\n\n" + fragment
+}
 
-	return &cfg, nil
+// mergeList appends the items of extra not already in list
+func mergeList(list, extra []string) []string {
+	merged := append([]string(nil), list...)
+	for _, item := range extra {
+		found := false
+		for _, existing := range merged {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
 }
 
 // GetPersona retrieves a persona by name
@@ -541,10 +677,70 @@ func (pc *PersonaConfig) GetPersona(name string) (*Persona, error) {
 	return &persona, nil
 }
 
-// FormatInstructions formats the persona instructions with task context
-func (p *Persona) FormatInstructions(task string) string {
+// RequestInfix separates the persona type from the name of a spawn request
+// directory ("<type>-request-<name>")
+const RequestInfix = "-request-"
+
+// Types returns the configured persona types, sorted
+func (pc *PersonaConfig) Types() []string {
+	types := make([]string, 0, len(pc.Personas))
+	for key := range pc.Personas {
+		types = append(types, key)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// RequestType returns the persona type a spawn request directory asks for. The
+// longest matching persona key wins.
+func (pc *PersonaConfig) RequestType(dirName string) (string, bool) {
+	return pc.longestKey(func(key string) bool {
+		return strings.HasPrefix(dirName, key+RequestInfix)
+	})
+}
+
+// SessionType returns the persona type of a "<type>-<timestamp>" session
+// directory. The longest matching persona key wins.
+func (pc *PersonaConfig) SessionType(dirName string) (string, bool) {
+	return pc.longestKey(func(key string) bool {
+		suffix := strings.TrimPrefix(dirName, key+"-")
+		if suffix == dirName || suffix == "" {
+			return false
+		}
+		for _, c := range suffix {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// longestKey returns the longest persona key accepted by match
+func (pc *PersonaConfig) longestKey(match func(key string) bool) (string, bool) {
+	best := ""
+	for key := range pc.Personas {
+		if len(key) > len(best) && match(key) {
+			best = key
+		}
+	}
+	return best, best != ""
+}
+
+// FormatInstructions formats the persona instructions with task context for a
+// single run outside a team, using workspace as {{.Workspace}}
+func (p *Persona) FormatInstructions(task, workspace string) (string, error) {
+	rendered, err := renderTemplate(p.Name+" instructions", p.Instructions, PromptContext{
+		Workspace: workspace,
+		Role:      p.Name,
+		Task:      task,
+	})
+	if err != nil {
+		return "", err
+	}
+
 	instructions := fmt.Sprintf("# Persona: %s\n\n", p.Name)
-	instructions += fmt.Sprintf("%s\n\n", p.Instructions)
+	instructions += fmt.Sprintf("%s\n\n", rendered)
 
 	if len(p.Capabilities) > 0 {
 		instructions += "## Your Capabilities:\n"
@@ -564,7 +760,7 @@ func (p *Persona) FormatInstructions(task string) string {
 
 	instructions += fmt.Sprintf("## Your Task:\n%s\n", task)
 
-	return instructions
+	return instructions, nil
 }
 
 // SaveDefaultPersonas saves the default personas to a file
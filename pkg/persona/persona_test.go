@@ -0,0 +1,160 @@
+package persona
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// routingConfig has persona keys that share prefixes
+func routingConfig() *PersonaConfig {
+	return &PersonaConfig{Personas: map[string]Persona{
+		"qa":                {Name: "QA"},
+		"qa-lead":           {Name: "QA Lead"},
+		"software-engineer": {Name: "Software Engineer"},
+		"data-engineer":     {Name: "Data Engineer"},
+	}}
+}
+
+func TestRequestType(t *testing.T) {
+	pc := routingConfig()
+	for dirName, want := range map[string]string{
+		"qa-request-smoke-tests":            "qa",
+		"qa-lead-request-test-plan":         "qa-lead",
+		"data-engineer-request-etl":         "data-engineer",
+		"software-engineer-request-1":       "software-engineer",
+		"engineer-request-1":                "",
+		"qa-lead-1706012345678":             "",
+		"security-auditor-request-pen-test": "",
+	} {
+		got, ok := pc.RequestType(dirName)
+		if got != want || ok != (want != "") {
+			t.Errorf("RequestType(%q) = %q, %v; want %q", dirName, got, ok, want)
+		}
+	}
+}
+
+func TestSessionType(t *testing.T) {
+	pc := routingConfig()
+	for dirName, want := range map[string]string{
+		"qa-1706012345678":               "qa",
+		"qa-lead-1706012345678":          "qa-lead",
+		"data-engineer-1706012345678":    "data-engineer",
+		"qa-":                            "",
+		"qa-lead":                        "",
+		"qa-request-smoke-tests":         "",
+		"qa-1706012345678-completed":     "",
+		"security-auditor-1706012345678": "",
+	} {
+		got, ok := pc.SessionType(dirName)
+		if got != want || ok != (want != "") {
+			t.Errorf("SessionType(%q) = %q, %v; want %q", dirName, got, ok, want)
+		}
+	}
+}
+
+func TestTypesAreSorted(t *testing.T) {
+	want := []string{"data-engineer", "qa", "qa-lead", "software-engineer"}
+	if got := routingConfig().Types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Types() = %v, want %v", got, want)
+	}
+}
+
+// loadPersonaFile loads personas from a temporary personas file
+func loadPersonaFile(t *testing.T, content string) (*PersonaConfig, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "personas.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return LoadPersonas(path)
+}
+
+func TestLoadPersonasResolvesExtendsAndMixins(t *testing.T) {
+	pc, err := loadPersonaFile(t, `
+personas:
+  software-engineer:
+    extends: software-engineer
+    instructions: Write Go.
+    capabilities: [Go]
+    mixins: [careful]
+  backend-engineer:
+    extends: software-engineer
+    name: Backend Engineer
+    model: opus
+    constraints: [Keep APIs backwards compatible]
+mixins:
+  careful:
+    instructions: Run the tests before every commit.
+    constraints: [Never force push]
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtin := DefaultPersonas().Personas["software-engineer"]
+
+	engineer := pc.Personas["software-engineer"]
+	if !strings.HasPrefix(engineer.Instructions, strings.TrimRight(builtin.Instructions, "\n")) ||
+		!strings.HasSuffix(engineer.Instructions, "Write Go.\n\nRun the tests before every commit.") {
+		t.Errorf("software-engineer instructions = %q", engineer.Instructions)
+	}
+	if engineer.Name != builtin.Name {
+		t.Errorf("software-engineer name = %q, want the built-in %q", engineer.Name, builtin.Name)
+	}
+	if want := append(append([]string{}, builtin.Capabilities...), "Go"); !reflect.DeepEqual(engineer.Capabilities, want) {
+		t.Errorf("software-engineer capabilities = %v, want %v", engineer.Capabilities, want)
+	}
+
+	// Extending a persona builds on its resolved form, mixins included
+	backend := pc.Personas["backend-engineer"]
+	if backend.Name != "Backend Engineer" || backend.Model != "opus" {
+		t.Errorf("backend-engineer = %q (%s)", backend.Name, backend.Model)
+	}
+	if !strings.Contains(backend.Instructions, "Run the tests before every commit.") {
+		t.Errorf("backend-engineer lost the mixin of its base: %q", backend.Instructions)
+	}
+	for _, want := range []string{"Never force push", "Keep APIs backwards compatible"} {
+		found := false
+		for _, c := range backend.Constraints {
+			found = found || c == want
+		}
+		if !found {
+			t.Errorf("backend-engineer constraints %v are missing %q", backend.Constraints, want)
+		}
+	}
+
+	// Personas the file does not mention keep their defaults
+	if _, ok := pc.Personas["qa"]; !ok {
+		t.Error("built-in qa persona dropped")
+	}
+}
+
+func TestLoadPersonasRejectsBadInheritance(t *testing.T) {
+	for name, tc := range map[string]struct{ file, want string }{
+		"cycle": {`
+personas:
+  a: {extends: b}
+  b: {extends: c}
+  c: {extends: a}
+`, "persona inheritance cycle"},
+		"unknown base": {`
+personas:
+  a: {extends: nobody}
+`, "extends unknown persona 'nobody'"},
+		"custom persona extends itself": {`
+personas:
+  a: {extends: a}
+`, "extends itself but is not a built-in persona"},
+		"unknown mixin": {`
+personas:
+  a: {mixins: [nothing]}
+`, "unknown mixin 'nothing'"},
+	} {
+		_, err := loadPersonaFile(t, tc.file)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want %q", name, err, tc.want)
+		}
+	}
+}
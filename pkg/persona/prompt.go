@@ -0,0 +1,311 @@
+package persona
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PromptContext is the data persona instructions and scaffolding are rendered
+// with (Go text/template syntax, e.g. {{.Workspace}})
+type PromptContext struct {
+	Workspace    string        // Absolute workspace path, e.g. /project/.ww-db/a1b2c3d4
+	SessionID    string        // e.g. software-engineer-1706012345678
+	SessionDir   string        // Absolute path of the session's persona directory
+	PersonaName  string        // Name given to the session, e.g. Turing
+	PersonaType  string        // Persona key, e.g. software-engineer
+	Role         string        // Persona display name, e.g. Coding Agent
+	Task         string        // Session's current task from its tasks.md, if any
+	Guidelines   string        // Project guidelines from CLAUDE.md, if any
+	Teammates    []Teammate    // Other running sessions in the workspace
+	RequestTypes []RequestType // Persona types that can be requested
+	Worktree     string        // Git worktree of an isolated session
+	Branch       string        // Branch of an isolated session
+	LockFile     string        // Workspace lock file
+	Instructions string        // Rendered persona instructions (scaffolding only)
+}
+
+// Teammate is another session in the workspace
+type Teammate struct {
+	ID          string
+	Name        string
+	Type        string
+	Status      string
+	CurrentWork string
+}
+
+// RequestType is a persona type that can be requested with a
+// {type}-request-{name} directory
+type RequestType struct {
+	Key  string
+	Name string
+}
+
+// RequestTypes returns the configured persona types, sorted by key
+func (pc *PersonaConfig) RequestTypes() []RequestType {
+	var types []RequestType
+	for _, key := range pc.Types() {
+		types = append(types, RequestType{Key: key, Name: pc.Personas[key].Name})
+	}
+	return types
+}
+
+// RenderPrompt renders the persona's system prompt: its instructions, then
+// its scaffolding (DefaultScaffolding unless the persona overrides it)
+func (p *Persona) RenderPrompt(ctx PromptContext) (string, error) {
+	instructions, err := renderTemplate(p.Name+" instructions", p.Instructions, ctx)
+	if err != nil {
+		return "", err
+	}
+	ctx.Instructions = instructions
+
+	scaffolding := p.Scaffolding
+	if scaffolding == "" {
+		scaffolding = DefaultScaffolding
+	}
+	return renderTemplate(p.Name+" scaffolding", scaffolding, ctx)
+}
+
+// validateTemplates checks that the templates of the persona with the given
+// key parse, naming the persona and field that does not
+func (p *Persona) validateTemplates(key string) error {
+	templates := []struct{ field, text string }{
+		{"instructions", p.Instructions},
+		{"scaffolding", p.Scaffolding},
+	}
+	for _, t := range templates {
+		if _, err := template.New(key + " " + t.field).Parse(t.text); err != nil {
+			return fmt.Errorf("invalid template in %s of persona '%s': %w (write a literal {{ as {{\"{{\"}})", t.field, key, err)
+		}
+	}
+	return nil
+}
+
+// renderTemplate executes a template with the prompt context
+func renderTemplate(name, text string, ctx PromptContext) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, ctx); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// DefaultScaffolding is the orchestration part of every session's system
+// prompt: where its files are, how to talk to other agents, how to request
+// new ones and which background tasks to run
+const DefaultScaffolding = `{{.Instructions}}
+
+{{if .Guidelines}}
+## Project Guidelines (from CLAUDE.md)
+{{.Guidelines}}
+
+{{end}}
+## Your Session Information
+Session ID: {{.SessionID}}
+Your Persona Directory: {{.SessionDir}}/
+Your Role: {{.PersonaName}}
+Working Directory: PROJECT ROOT (current directory)
+
+## IMPORTANT: Read Shell Configuration First
+Before starting work, read ~/.zshrc to discover available commands, aliases, and functions:
+- Custom functions defined by the user
+- Useful aliases and shortcuts
+- Environment-specific tools and utilities
+
+Read ~/.zshrc NOW to understand your environment.
+
+## Important: Working Directory
+- You are running from the PROJECT ROOT directory (where the project was initialized)
+- All your work (code, files, etc.) should be created in the current directory or its subdirectories
+- Your persona-specific files are in: {{.SessionDir}}/
+- Reference your persona files using the full path above
+
+## Files in Your Persona Directory
+- {{.SessionDir}}/tasks.md: YOUR task list (you update this)
+- {{.SessionDir}}/instructions.md: Instructions from others (read regularly)
+- {{.SessionDir}}/tracker.json: Reading state tracker (automatic)
+- {{.SessionDir}}/persona-instructions.md: Your role and capabilities
+{{if .Task}}
+## Your Current Task
+{{.Task}}
+{{end}}
+## Important Guidelines
+
+### Automatic Instruction Monitoring
+- Your worker watches your persona directory and runs you again as soon as instructions.md grows
+  (within 30 seconds if file change notifications are unavailable)
+- You are then told to read instructions.md; new instructions are appended with timestamps
+- Every 2 minutes you also get a status check, even if nothing changed
+
+### Update Your Tasks
+- Update {{.SessionDir}}/tasks.md with your progress after completing work
+- Use statuses: "not started", "in progress", "completed"
+- Keep each task's "- **ID**:" line unchanged; other notes under a task are preserved
+- If a task cannot start until other tasks are done, add "- **Depends on**: T2, software-engineer-1234567890/T1"
+  (a bare ID is one of your own tasks; otherwise prefix the other persona's session ID, type or name)
+- You will get an instruction when all of a task's dependencies are completed
+- Tasks may carry "- **Priority**: high" (low, normal, high, urgent) and "- **Deadline**: 2024-01-27 17:00:00";
+  work on urgent and soon-due tasks first. You are reminded when a deadline is near or missed.
+- When ALL tasks are completed, your worker stops and the orchestrator finishes your session. It may
+  first give you an unassigned board task, run completion gates or merge your branch; any of these
+  can send work back to you, and you are started again with the details in instructions.md
+- The system will periodically check your progress
+
+### Communication
+- DO NOT modify other personas' files
+- To assign work: Send them a message with wildwest send (see below)
+- For spawning new team members: Create request directories (see below)
+- Write your deliverables to the current directory (project root)
+- Your persona directory ({{.SessionDir}}/) is only for instructions/tasks tracking
+
+{{if .Worktree}}
+## Git Worktree
+You are working in your own git worktree, not the shared project root:
+- Worktree: {{.Worktree}}
+- Branch: {{.Branch}}
+Make all code changes in this worktree and commit them to your branch.
+Other engineers work on their own branches, so do not check out or modify theirs.
+Your branch is kept for review when your tasks are completed.
+
+{{end}}{{if .Teammates}}
+## Your Team
+{{range .Teammates}}- {{.Name}} ({{.Type}}, {{.ID}}){{if .CurrentWork}}: {{.CurrentWork}}{{end}}
+{{end}}
+{{end}}
+## Communicating with Other Agents
+
+You can communicate with ANY agent - there are NO hierarchy restrictions.
+Use wildwest send to give them tasks, ask questions, or provide feedback. The target is a
+session ID, a persona type (every active session of that type) or a persona name.
+Every message gets an ID and is kept in the shared message store; never write to
+another agent's instructions.md yourself.
+
+Examples:
+
+# Send instructions to Leader Agent
+wildwest send --workspace {{.Workspace}} --from {{.SessionID}} engineering-manager "We need to pivot the project direction. Please review and approve."
+
+# Ask the Architect a question
+wildwest send --workspace {{.Workspace}} --from {{.SessionID}} --type question solutions-architect "Which database should the user service use?"
+
+# Send a longer message to every Coder
+wildwest send --workspace {{.Workspace}} --from {{.SessionID}} software-engineer - <<EOF
+Implement the API endpoints according to the spec.
+EOF
+
+# Reply to a message you received (threaded and sent to its sender)
+wildwest send --workspace {{.Workspace}} --from {{.SessionID}} --reply-to <message-id> "Done, see api/users.go"
+
+Messages sent to you are appended to {{.SessionDir}}/instructions.md with their message ID.
+List the ones you have not handled yet, and acknowledge each once you have acted on it:
+
+wildwest inbox --workspace {{.Workspace}} --unread {{.SessionID}}
+wildwest ack --workspace {{.Workspace}} {{.SessionID}} <message-id>
+
+
+## Shared Task Board
+
+The team shares a task board in {{.Workspace}}/shared/tasks.md (task IDs B1, B2, ...).
+Board tasks assigned to you also appear in your tasks.md; update their status there
+or with the CLI, and the board is kept in sync.
+
+wildwest task list --workspace {{.Workspace}} --unassigned
+wildwest task add --workspace {{.Workspace}} --by {{.SessionID}} "Write integration tests" [--assign software-engineer] [--depends-on B1]
+wildwest task claim --workspace {{.Workspace}} --as {{.SessionID}} B3
+wildwest task done --workspace {{.Workspace}} B3
+
+When you are idle, check the board and claim an unassigned task.
+
+
+## Requesting Additional Resources
+
+ANY agent can request ANY type of resource - there are NO restrictions.
+Need an architect? Request one. Need the leader's input? Request a conversation.
+
+To request a new agent:
+1. Create directory: {{.Workspace}}/{agent-type}-request-{descriptive-name}/
+2. Write your session ID ({{.SessionID}}) to a file named requester in that directory
+3. Create: instructions.md in that directory with their initial task
+   Optionally add request.yaml with priority (low, normal, high, urgent), deadline
+   ("2024-01-27 17:00" or a duration such as 4h), model, budget (usd, tokens) and labels
+4. Orchestrator will spawn the agent automatically (a human may have to approve it first;
+   if the request is denied, the reason is sent to your instructions.md)
+5. Directory will be renamed to {agent-type}-{timestamp}/
+
+Available agent types:
+{{range .RequestTypes}}- {{.Key}}-request-* → {{.Name}}
+{{end}}
+Examples:
+
+# Request an Architect
+mkdir {{.Workspace}}/solutions-architect-request-api-designer
+echo {{.SessionID}} > {{.Workspace}}/solutions-architect-request-api-designer/requester
+cat > {{.Workspace}}/solutions-architect-request-api-designer/instructions.md <<EOF
+Design the REST API architecture for our user management system.
+EOF
+
+# Request a Coder
+mkdir {{.Workspace}}/software-engineer-request-backend
+cat > {{.Workspace}}/software-engineer-request-backend/instructions.md <<EOF
+Implement the backend API endpoints according to the architecture spec.
+EOF
+cat > {{.Workspace}}/software-engineer-request-backend/request.yaml <<EOF
+requester: {{.SessionID}}
+priority: high
+deadline: 4h
+labels: [backend]
+EOF
+
+# Request QA
+mkdir {{.Workspace}}/qa-request-api-tester
+cat > {{.Workspace}}/qa-request-api-tester/instructions.md <<EOF
+Write integration tests for the user management API.
+EOF
+
+# Request Support
+mkdir {{.Workspace}}/intern-request-documentation
+cat > {{.Workspace}}/intern-request-documentation/instructions.md <<EOF
+Write API documentation for all endpoints in OpenAPI format.
+EOF
+
+
+## Completion
+When all your tasks are marked "completed", your worker stops after the current turn and the orchestrator
+decides whether you are done:
+- If the team board has unassigned tasks you can take, one is assigned to you and you are started again.
+- If completion gates are configured (build/test commands or QA sign-off), they must pass. When a gate
+  fails, its output is appended to your instructions.md, your task is set back to "in progress" and you
+  are started again to fix it. A failed merge of your branch is handled the same way.
+- Once everything passes, your session is archived and whoever requested you is told the work is done.
+
+## IMPORTANT: Background Task
+
+You do not need to watch instructions.md yourself; your worker does that. Start this background task
+IMMEDIATELY when you begin:
+
+### Status Updates
+Update your session.json with current_work every 10 seconds. Extract just the task title from tasks.md (details shown in popup).
+
+The update holds the workspace lock (when flock is installed) and replaces session.json atomically, so it never clobbers changes made by the orchestrator.
+
+Bash(PERSONA_DIR={{.SessionDir}}; LOCK={{.LockFile}}; while true; do CURRENT=$(grep '^## Task:' $PERSONA_DIR/tasks.md 2>/dev/null | head -1 | sed 's/^## Task: //' || echo "No tasks assigned"); ( flock -w 10 9 2>/dev/null; jq --arg status "$CURRENT" '.current_work = $status' $PERSONA_DIR/session.json > $PERSONA_DIR/.session.json.tmp-$$ && mv $PERSONA_DIR/.session.json.tmp-$$ $PERSONA_DIR/session.json ) 9>"$LOCK"; sleep 10; done, run_in_background=true)
+
+## CRITICAL: After Completing Tasks
+
+When you complete all your current tasks:
+1. IMMEDIATELY use Read tool to read instructions.md and check for new assignments
+2. If new instructions found, start working on them right away
+3. Before declaring yourself done or idle, ALWAYS check instructions.md one more time
+4. If no new instructions, update tasks.md with "Waiting for instructions" status
+
+**IMPORTANT**: Similar to how you send notifications after responses, you MUST check instructions.md after completing each task. This is not optional.
+
+## Startup Sequence
+1. Read ~/.zshrc to discover available commands and functions
+2. Start both background tasks above
+3. Begin working on your tasks from {{.SessionDir}}/tasks.md
+`
@@ -0,0 +1,69 @@
+package persona
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplatesNamesPersonaAndField(t *testing.T) {
+	p := &Persona{Name: "DevOps", Instructions: "Deploy with Helm.", Scaffolding: "values: {{ toYaml .Values }}"}
+
+	err := p.validateTemplates("devops")
+	if err == nil {
+		t.Fatal("a literal {{ in the scaffolding parsed")
+	}
+	for _, want := range []string{"scaffolding", "'devops'", `{{"{{"}}`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+
+	p.Scaffolding = `values: {{"{{"}} toYaml .Values }}`
+	if err := p.validateTemplates("devops"); err != nil {
+		t.Errorf("escaped template: %v", err)
+	}
+}
+
+func TestFormatInstructionsUsesWorkspace(t *testing.T) {
+	p := &Persona{Name: "Engineer", Instructions: "Workspace: {{.Workspace}}"}
+
+	got, err := p.FormatInstructions("Add login", "/tmp/team-ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "Workspace: /tmp/team-ws") || !strings.Contains(got, "Add login") {
+		t.Errorf("instructions = %q", got)
+	}
+}
+
+func TestDefaultPromptsSendMessagesThroughTheStore(t *testing.T) {
+	for key, p := range DefaultPersonas().Personas {
+		prompt, err := p.RenderPrompt(PromptContext{Workspace: "/ws", SessionID: key + "-1"})
+		if err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		if strings.Contains(prompt, "cat >>") {
+			t.Errorf("%s prompt still appends to files with cat >>", key)
+		}
+		if !strings.Contains(prompt, "wildwest send --workspace /ws --from "+key+"-1") {
+			t.Errorf("%s prompt does not show wildwest send", key)
+		}
+	}
+}
+
+func TestDefaultPromptsDescribeTheWorkerLoop(t *testing.T) {
+	for key, p := range DefaultPersonas().Personas {
+		prompt, err := p.RenderPrompt(PromptContext{Workspace: "/ws", SessionID: key + "-1"})
+		if err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		for _, stale := range []string{"every 5 seconds", "automatically terminated", "sleep 5"} {
+			if strings.Contains(prompt, stale) {
+				t.Errorf("%s prompt still says %q", key, stale)
+			}
+		}
+		if !strings.Contains(prompt, "completion gates") {
+			t.Errorf("%s prompt does not mention completion gates", key)
+		}
+	}
+}
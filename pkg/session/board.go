@@ -0,0 +1,234 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BoardTaskPrefix starts the ID of every task on the shared task board, so board
+// tasks can be told apart from a persona's own T<n> tasks
+const BoardTaskPrefix = "B"
+
+// boardPreamble heads a new task board file
+const boardPreamble = `# Task Board
+
+Shared tasks for the whole team. Unassigned tasks can be claimed by any idle persona:
+    wildwest task claim <task-id> --as <session-id>
+Each task assigned to a persona also appears in that persona's tasks.md.`
+
+// IsBoardTask reports whether a task ID belongs to the shared task board
+func IsBoardTask(id string) bool {
+	if !strings.HasPrefix(id, BoardTaskPrefix) || len(id) == len(BoardTaskPrefix) {
+		return false
+	}
+	for _, c := range id[len(BoardTaskPrefix):] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// BoardPath returns the path of the shared task board
+func (sm *SessionManager) BoardPath() string {
+	return filepath.Join(sm.workspacePath, "shared", "tasks.md")
+}
+
+// LoadBoard reads and parses the shared task board
+func (sm *SessionManager) LoadBoard() (*TaskList, error) {
+	data, err := os.ReadFile(sm.BoardPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &TaskList{Preamble: boardPreamble}, nil
+		}
+		return nil, err
+	}
+	return ParseTasks(string(data)), nil
+}
+
+// AddBoardTask puts a new task on the board. assignTo may be empty to leave the
+// task open for anyone to claim; priority and deadline are optional.
+func (sm *SessionManager) AddBoardTask(description, assignedBy, assignTo string, dependsOn []string, priority string, deadline time.Time) (*Task, error) {
+	var added Task
+	err := sm.modifyBoard(func(board *TaskList) error {
+		task := board.AddWithPrefix(BoardTaskPrefix, description, assignedBy)
+		task.AssignedTo = assignTo
+		task.DependsOn = dependsOn
+		task.Priority = priority
+		task.Deadline = deadline
+		added = *task
+		return nil
+	})
+	return &added, err
+}
+
+// ClaimBoardTask assigns an unassigned board task to a session and marks it in
+// progress. An empty taskID claims the first open, unassigned task.
+func (sm *SessionManager) ClaimBoardTask(taskID, sessionID string) (*Task, error) {
+	if _, err := sm.GetSession(sessionID); err != nil {
+		return nil, fmt.Errorf("session %s not found", sessionID)
+	}
+
+	var claimed Task
+	err := sm.modifyBoard(func(board *TaskList) error {
+		var task *Task
+		if taskID == "" {
+			for i := range board.Tasks {
+				if board.Tasks[i].Claimable() {
+					task = &board.Tasks[i]
+					break
+				}
+			}
+			if task == nil {
+				return fmt.Errorf("no unassigned tasks on the board")
+			}
+		} else {
+			task = board.Find(taskID)
+			if task == nil {
+				return fmt.Errorf("task %s not found on the board", taskID)
+			}
+			if task.AssignedTo != "" && task.AssignedTo != sessionID {
+				return fmt.Errorf("task %s is already claimed by %s", taskID, task.AssignedTo)
+			}
+			if task.Status == TaskStatusCompleted {
+				return fmt.Errorf("task %s is already completed", taskID)
+			}
+		}
+
+		task.AssignedTo = sessionID
+		task.Status = TaskStatusInProgress
+		task.UpdatedAt = time.Now()
+		claimed = *task
+		return nil
+	})
+	return &claimed, err
+}
+
+// UpdateBoardTask sets the status of a board task
+func (sm *SessionManager) UpdateBoardTask(taskID string, status TaskStatus) (*Task, error) {
+	var updated Task
+	err := sm.modifyBoard(func(board *TaskList) error {
+		if err := board.SetStatus(taskID, status); err != nil {
+			return fmt.Errorf("task %s not found on the board", taskID)
+		}
+		updated = *board.Find(taskID)
+		return nil
+	})
+	return &updated, err
+}
+
+// SyncBoard reconciles the board with the personas' tasks.md views: status
+// changes agents made in their own tasks.md are copied to the board, then every
+// view is rewritten from the board
+func (sm *SessionManager) SyncBoard() error {
+	return sm.modifyBoard(nil)
+}
+
+// modifyBoard syncs the board with the persona views, applies modify and writes
+// the board and any changed views, all under the workspace lock
+func (sm *SessionManager) modifyBoard(modify func(*TaskList) error) error {
+	return sm.withLock(func() error {
+		board, err := sm.LoadBoard()
+		if err != nil {
+			return err
+		}
+		before := board.String()
+
+		sessions, err := sm.GetAllSessions()
+		if err != nil {
+			return err
+		}
+		views := make(map[string]*TaskList)
+		for _, sess := range sessions {
+			if tl, err := sm.LoadTasks(sess.ID); err == nil {
+				views[sess.ID] = tl
+			}
+		}
+
+		pullBoardViews(board, views)
+
+		if modify != nil {
+			if err := modify(board); err != nil {
+				return err
+			}
+		}
+
+		if after := board.String(); after != before {
+			if err := WriteFileAtomic(sm.BoardPath(), []byte(after), 0644); err != nil {
+				return fmt.Errorf("failed to write task board: %w", err)
+			}
+		}
+
+		for sessionID, view := range views {
+			before := view.String()
+			pushBoardView(board, sessionID, view)
+			if after := view.String(); after != before {
+				if err := sm.writeTasks(sessionID, after); err != nil {
+					return fmt.Errorf("failed to update tasks for %s: %w", sessionID, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// pullBoardViews copies the status of board tasks from their assignees' views.
+// A board entry with a newer Updated time than the view wins instead, so direct
+// edits to the board are not reverted by stale views.
+func pullBoardViews(board *TaskList, views map[string]*TaskList) {
+	for sessionID, view := range views {
+		for _, viewTask := range view.Tasks {
+			if !IsBoardTask(viewTask.ID) {
+				continue
+			}
+			task := board.Find(viewTask.ID)
+			if task == nil || task.AssignedTo != sessionID || task.Status == viewTask.Status {
+				continue
+			}
+			if task.UpdatedAt.Truncate(time.Second).After(viewTask.UpdatedAt) {
+				continue
+			}
+			task.Status = viewTask.Status
+			task.UpdatedAt = viewTask.UpdatedAt
+			if task.UpdatedAt.IsZero() {
+				task.UpdatedAt = time.Now()
+			}
+		}
+	}
+}
+
+// pushBoardView makes a persona's view match the board: board tasks assigned to
+// the persona are added or refreshed (keeping the persona's notes) and board
+// tasks assigned elsewhere are removed. The persona's own tasks are untouched.
+func pushBoardView(board *TaskList, sessionID string, view *TaskList) {
+	present := make(map[string]bool)
+	tasks := view.Tasks[:0:0]
+	for _, viewTask := range view.Tasks {
+		if !IsBoardTask(viewTask.ID) {
+			tasks = append(tasks, viewTask)
+			continue
+		}
+		task := board.Find(viewTask.ID)
+		if task == nil || task.AssignedTo != sessionID {
+			continue
+		}
+		refreshed := *task
+		refreshed.Notes = viewTask.Notes
+		tasks = append(tasks, refreshed)
+		present[task.ID] = true
+	}
+
+	for _, task := range board.Tasks {
+		if task.AssignedTo == sessionID && !present[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+
+	if len(tasks) > 0 && len(view.Tasks) == 0 {
+		view.Preamble = strings.TrimRight(strings.Replace(view.Preamble, noTasksPlaceholder, "", 1), "\n")
+	}
+	view.Tasks = tasks
+}
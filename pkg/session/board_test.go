@@ -0,0 +1,143 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestManager creates a session manager on a temporary workspace
+func newTestManager(t *testing.T) *SessionManager {
+	t.Helper()
+	sm, err := NewSessionManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sm
+}
+
+// createTestSession creates a session, waiting so session IDs never collide
+func createTestSession(t *testing.T, sm *SessionManager, personaType SessionType) *Session {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond)
+	sess, err := sm.CreateSession(personaType, "", "test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestIsBoardTask(t *testing.T) {
+	for id, want := range map[string]bool{"B1": true, "B12": true, "B": false, "T1": false, "Bx": false} {
+		if got := IsBoardTask(id); got != want {
+			t.Errorf("IsBoardTask(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestBoardClaimSyncsPersonaView(t *testing.T) {
+	sm := newTestManager(t)
+	eng := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	if err := sm.AddTask(eng.ID, "Own work", "system"); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := sm.AddBoardTask("Write integration tests", "system", "", nil, "", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.ID != "B1" || !added.Claimable() {
+		t.Fatalf("added task = %+v", added)
+	}
+
+	claimed, err := sm.ClaimBoardTask("", eng.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed.ID != "B1" || claimed.AssignedTo != eng.ID || claimed.Status != TaskStatusInProgress {
+		t.Errorf("claimed task = %+v", claimed)
+	}
+
+	view, err := sm.LoadTasks(eng.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(view.Tasks) != 2 || view.Find("T1") == nil || view.Find("B1") == nil {
+		t.Fatalf("view after claim = %+v", view.Tasks)
+	}
+
+	if _, err := sm.ClaimBoardTask("", eng.ID); err == nil {
+		t.Error("claimed a task from a board with none open")
+	}
+}
+
+func TestBoardPullsStatusFromView(t *testing.T) {
+	sm := newTestManager(t)
+	eng := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	if _, err := sm.AddBoardTask("Fix flaky test", "system", eng.ID, nil, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The engineer completes the task in their own tasks.md
+	time.Sleep(time.Second)
+	if err := sm.SetTaskStatus(eng.ID, "B1", TaskStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.SyncBoard(); err != nil {
+		t.Fatal(err)
+	}
+
+	board, err := sm.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := board.Find("B1"); task == nil || task.Status != TaskStatusCompleted {
+		t.Errorf("board task after sync = %+v", task)
+	}
+}
+
+func TestBoardReassignMovesTaskBetweenViews(t *testing.T) {
+	sm := newTestManager(t)
+	first := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	second := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	if _, err := sm.AddBoardTask("Update docs", "system", first.ID, nil, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reassign on the board directly
+	err := sm.modifyBoard(func(board *TaskList) error {
+		task := board.Find("B1")
+		task.AssignedTo = second.ID
+		task.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if view, _ := sm.LoadTasks(first.ID); view.Find("B1") != nil {
+		t.Error("task still in the previous assignee's view")
+	}
+	if view, _ := sm.LoadTasks(second.ID); view.Find("B1") == nil {
+		t.Error("task missing from the new assignee's view")
+	}
+}
+
+func TestPushBoardViewKeepsNotes(t *testing.T) {
+	board := &TaskList{Tasks: []Task{{ID: "B1", Description: "Shared", Status: TaskStatusInProgress, AssignedTo: "s1"}}}
+	view := &TaskList{Tasks: []Task{
+		{ID: "T1", Description: "Own", Status: TaskStatusNotStarted},
+		{ID: "B1", Description: "Old title", Status: TaskStatusNotStarted, Notes: "halfway there"},
+	}}
+
+	pushBoardView(board, "s1", view)
+
+	if len(view.Tasks) != 2 {
+		t.Fatalf("view = %+v", view.Tasks)
+	}
+	b1 := view.Find("B1")
+	if b1.Description != "Shared" || b1.Status != TaskStatusInProgress || b1.Notes != "halfway there" {
+		t.Errorf("refreshed board task = %+v", b1)
+	}
+}
@@ -0,0 +1,66 @@
+package session
+
+// LineageNode is a session in the spawn tree with the sessions it requested
+type LineageNode struct {
+	Session  *Session
+	Children []*LineageNode
+}
+
+// BuildLineage arranges sessions into spawn trees by ParentSessionID. Sessions
+// whose parent is not among them are roots. Roots and children keep the order
+// of sessions.
+func BuildLineage(sessions []*Session) []*LineageNode {
+	nodes := make(map[string]*LineageNode, len(sessions))
+	for _, sess := range sessions {
+		nodes[sess.ID] = &LineageNode{Session: sess}
+	}
+
+	var roots []*LineageNode
+	for _, sess := range sessions {
+		node := nodes[sess.ID]
+		parent, ok := nodes[sess.ParentSessionID]
+		if !ok || parent == node || isDescendant(parent, node) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
+// isDescendant reports whether node is below ancestor, guarding BuildLineage
+// against parent cycles in hand-edited session files
+func isDescendant(node, ancestor *LineageNode) bool {
+	for _, child := range ancestor.Children {
+		if child == node || isDescendant(node, child) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ancestors returns the chain of sessions that requested a session, nearest
+// first. Archived sessions are included.
+func (sm *SessionManager) Ancestors(sess *Session) ([]*Session, error) {
+	history, err := sm.GetSessionHistory()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Session, len(history))
+	for _, s := range history {
+		byID[s.ID] = s
+	}
+
+	var chain []*Session
+	seen := map[string]bool{sess.ID: true}
+	for id := sess.ParentSessionID; id != "" && !seen[id]; {
+		parent, ok := byID[id]
+		if !ok {
+			break
+		}
+		chain = append(chain, parent)
+		seen[id] = true
+		id = parent.ParentSessionID
+	}
+	return chain, nil
+}
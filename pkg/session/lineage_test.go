@@ -0,0 +1,103 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lineageIDs flattens spawn trees into "id(child ids...)" strings in order
+func lineageIDs(nodes []*LineageNode) []string {
+	var ids []string
+	for _, node := range nodes {
+		id := node.Session.ID
+		if len(node.Children) > 0 {
+			id += "(" + strings.Join(lineageIDs(node.Children), " ") + ")"
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestBuildLineage(t *testing.T) {
+	sessions := []*Session{
+		{ID: "eng-2", ParentSessionID: "em"},
+		{ID: "em", ParentSessionID: "pm"},
+		{ID: "pm"},
+		{ID: "qa", ParentSessionID: "eng-2"},
+		{ID: "eng-1", ParentSessionID: "em"},
+		{ID: "orphan", ParentSessionID: "gone"},
+	}
+
+	want := []string{"pm(em(eng-2(qa) eng-1))", "orphan"}
+	if got := lineageIDs(BuildLineage(sessions)); !reflect.DeepEqual(got, want) {
+		t.Errorf("lineage = %v, want %v", got, want)
+	}
+}
+
+func TestBuildLineageBreaksParentCycles(t *testing.T) {
+	sessions := []*Session{
+		{ID: "a", ParentSessionID: "b"},
+		{ID: "b", ParentSessionID: "a"},
+		{ID: "self", ParentSessionID: "self"},
+	}
+
+	want := []string{"b(a)", "self"}
+	if got := lineageIDs(BuildLineage(sessions)); !reflect.DeepEqual(got, want) {
+		t.Errorf("lineage = %v, want %v", got, want)
+	}
+}
+
+func TestAncestorsIncludeArchivedSessions(t *testing.T) {
+	sm := newTestManager(t)
+	manager := createTestSession(t, sm, SessionTypeEngineeringManager)
+	engineer := createChildSession(t, sm, SessionTypeSoftwareEngineer, manager.ID)
+	qa := createChildSession(t, sm, SessionTypeQA, engineer.ID)
+
+	if err := os.Rename(filepath.Join(sm.workspacePath, manager.ID), filepath.Join(sm.workspacePath, manager.ID+"-archived")); err != nil {
+		t.Fatal(err)
+	}
+
+	chain, err := sm.Ancestors(qa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, sess := range chain {
+		ids = append(ids, sess.ID)
+	}
+	if want := []string{engineer.ID, manager.ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ancestors = %v, want %v", ids, want)
+	}
+}
+
+func TestAncestorsStopAtCycles(t *testing.T) {
+	sm := newTestManager(t)
+	first := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	second := createChildSession(t, sm, SessionTypeQA, first.ID)
+	if err := sm.UpdateSession(first.ID, func(s *Session) { s.ParentSessionID = second.ID }); err != nil {
+		t.Fatal(err)
+	}
+
+	chain, err := sm.Ancestors(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 1 || chain[0].ID != first.ID {
+		t.Errorf("ancestors = %v, want just %s", chain, first.ID)
+	}
+}
+
+// createChildSession creates a session requested by parentID
+func createChildSession(t *testing.T, sm *SessionManager, personaType SessionType, parentID string) *Session {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond)
+	sess, err := sm.CreateSession(personaType, "", "test", parentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
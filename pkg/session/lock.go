@@ -0,0 +1,65 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// LockFileName is the advisory lock file at the root of a workspace. Every
+// SessionManager mutation holds an exclusive flock on it, and worker scripts
+// can take the same lock with flock(1) before touching workspace files.
+const LockFileName = ".wildwest.lock"
+
+// LockPath returns the path of the workspace lock file
+func (sm *SessionManager) LockPath() string {
+	return filepath.Join(sm.workspacePath, LockFileName)
+}
+
+// withLock runs fn while holding the exclusive workspace lock.
+// flock is not reentrant, so fn must not call another locking method.
+func (sm *SessionManager) withLock(fn func() error) error {
+	file, err := os.OpenFile(sm.LockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open workspace lock: %w", err)
+	}
+	defer file.Close()
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock workspace: %w", err)
+	}
+	defer syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+
+	return fn()
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it
+// into place, so readers never observe a partially written file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
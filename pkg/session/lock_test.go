@@ -0,0 +1,109 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestConcurrentTaskUpdatesAreSerialized(t *testing.T) {
+	sm := newTestManager(t)
+	sess := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	// Separate managers stand in for the orchestrator and CLI processes
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			other, err := NewSessionManager(sm.GetWorkspacePath())
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- other.AddTask(sess.ID, fmt.Sprintf("Task %d", i), "test")
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tasks, err := sm.LoadTasks(sess.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool)
+	for _, task := range tasks.Tasks {
+		ids[task.ID] = true
+	}
+	if len(tasks.Tasks) != writers || len(ids) != writers {
+		t.Errorf("got %d tasks with %d distinct IDs, want %d", len(tasks.Tasks), len(ids), writers)
+	}
+}
+
+func TestMutationsWaitForTheWorkspaceLock(t *testing.T) {
+	sm := newTestManager(t)
+	sess := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	// Hold the lock the way a worker script does with flock(1)
+	file, err := os.OpenFile(sm.LockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sm.AddTask(sess.ID, "Blocked task", "test") }()
+
+	select {
+	case <-done:
+		t.Fatal("AddTask ran while another process held the workspace lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AddTask did not finish after the lock was released")
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "new" {
+		t.Errorf("content = %q, %v", data, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, %v", info.Mode().Perm(), err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
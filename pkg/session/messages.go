@@ -0,0 +1,376 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Message types
+const (
+	MessageTypeTask         = "task"
+	MessageTypeQuestion     = "question"
+	MessageTypeResponse     = "response"
+	MessageTypeNotification = "notification"
+	MessageTypeSignoff      = "signoff" // QA approval that lets a session complete
+)
+
+// MessageTypes lists the valid message types
+var MessageTypes = []string{MessageTypeTask, MessageTypeQuestion, MessageTypeResponse, MessageTypeNotification, MessageTypeSignoff}
+
+// broadcastInbox is the inbox file name holding messages sent to everyone
+const broadcastInbox = "broadcast"
+
+// messageAck records that a recipient has handled a message
+type messageAck struct {
+	MessageID string    `json:"message_id"`
+	AckedAt   time.Time `json:"acked_at"`
+}
+
+// messagesDir returns the directory holding the workspace message store.
+//
+// Layout:
+//
+//	shared/messages/<session-id>.jsonl   messages addressed to a session
+//	shared/messages/broadcast.jsonl      messages addressed to everyone
+//	shared/messages/acks/<session-id>.jsonl
+//
+// All files are append-only. instructions.md is rendered from the store.
+func (sm *SessionManager) messagesDir() string {
+	return filepath.Join(sm.workspacePath, "shared", "messages")
+}
+
+func (sm *SessionManager) inboxPath(sessionID string) string {
+	return filepath.Join(sm.messagesDir(), sessionID+".jsonl")
+}
+
+func (sm *SessionManager) acksPath(sessionID string) string {
+	return filepath.Join(sm.messagesDir(), "acks", sessionID+".jsonl")
+}
+
+// SendMessage stores a message and appends it to the recipients' instructions.md.
+// An empty To broadcasts the message to every active session except the sender.
+func (sm *SessionManager) SendMessage(msg *Message) error {
+	if msg.ID == "" {
+		msg.ID = GenerateMessageID()
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	if msg.Type == "" {
+		msg.Type = MessageTypeTask
+	}
+	if msg.FromPersona == "" {
+		if from, err := sm.GetSession(msg.From); err == nil {
+			msg.FromPersona = from.PersonaType
+		}
+	}
+
+	var recipients []string
+	inbox := broadcastInbox
+	if msg.To != "" {
+		if msg.ToPersona == "" {
+			if to, err := sm.GetSession(msg.To); err == nil {
+				msg.ToPersona = to.PersonaType
+			}
+		}
+		inbox = msg.To
+		recipients = []string{msg.To}
+	} else {
+		active, err := sm.GetActiveSessions()
+		if err != nil {
+			return err
+		}
+		for _, s := range active {
+			if s.ID != msg.From {
+				recipients = append(recipients, s.ID)
+			}
+		}
+	}
+
+	return sm.withLock(func() error {
+		if err := appendJSONLine(filepath.Join(sm.messagesDir(), inbox+".jsonl"), msg); err != nil {
+			return fmt.Errorf("failed to store message: %w", err)
+		}
+
+		// Keep instructions.md in sync for agents that read it directly
+		section := renderMessage(msg)
+		for _, recipient := range recipients {
+			instructionsPath := filepath.Join(sm.getPersonaDir(recipient), "instructions.md")
+			if err := appendFile(instructionsPath, section); err != nil {
+				return fmt.Errorf("failed to update instructions for %s: %w", recipient, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Reply sends a message threaded under an existing message, addressed to its
+// sender. An empty msgType sends a response.
+func (sm *SessionManager) Reply(parentID, fromSessionID, msgType, content string) (*Message, error) {
+	parent, err := sm.GetMessage(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := parent.Subject
+	if subject != "" && !strings.HasPrefix(subject, "Re: ") {
+		subject = "Re: " + subject
+	}
+
+	if msgType == "" {
+		msgType = MessageTypeResponse
+	}
+	msg := &Message{
+		From:     fromSessionID,
+		To:       parent.From,
+		Type:     msgType,
+		Subject:  subject,
+		Content:  content,
+		ParentID: parent.ID,
+	}
+	if err := sm.SendMessage(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ReadInbox returns the messages addressed to a session, including broadcasts
+// from others sent since the session started, oldest first. With unreadOnly,
+// acknowledged messages are skipped.
+func (sm *SessionManager) ReadInbox(sessionID string, unreadOnly bool) ([]Message, error) {
+	direct, err := readMessages(sm.inboxPath(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	broadcast, err := readMessages(sm.inboxPath(broadcastInbox))
+	if err != nil {
+		return nil, err
+	}
+
+	// Broadcasts only reach sessions that were running when they were sent
+	var since time.Time
+	if sess, err := sm.GetSession(sessionID); err == nil {
+		since = sess.StartTime
+	}
+
+	messages := direct
+	for _, msg := range broadcast {
+		if msg.From != sessionID && !msg.Timestamp.Before(since) {
+			messages = append(messages, msg)
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+
+	if !unreadOnly {
+		return messages, nil
+	}
+
+	acked, err := sm.ackedMessages(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var unread []Message
+	for _, msg := range messages {
+		if !acked[msg.ID] {
+			unread = append(unread, msg)
+		}
+	}
+	return unread, nil
+}
+
+// Ack marks a message as handled by a session
+func (sm *SessionManager) Ack(sessionID, messageID string) error {
+	return sm.withLock(func() error {
+		return appendJSONLine(sm.acksPath(sessionID), messageAck{MessageID: messageID, AckedAt: time.Now()})
+	})
+}
+
+// ackedMessages returns the set of message IDs a session has acknowledged
+func (sm *SessionManager) ackedMessages(sessionID string) (map[string]bool, error) {
+	acked := make(map[string]bool)
+
+	file, err := os.Open(sm.acksPath(sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return acked, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var ack messageAck
+		if err := json.Unmarshal(scanner.Bytes(), &ack); err == nil {
+			acked[ack.MessageID] = true
+		}
+	}
+	return acked, scanner.Err()
+}
+
+// allMessages returns every message in the workspace store
+func (sm *SessionManager) allMessages() ([]Message, error) {
+	files, err := filepath.Glob(filepath.Join(sm.messagesDir(), "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []Message
+	for _, file := range files {
+		msgs, err := readMessages(file)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msgs...)
+	}
+	return messages, nil
+}
+
+// GetMessage finds a message by ID
+func (sm *SessionManager) GetMessage(messageID string) (*Message, error) {
+	messages, err := sm.allMessages()
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		if messages[i].ID == messageID {
+			return &messages[i], nil
+		}
+	}
+	return nil, fmt.Errorf("message %s not found", messageID)
+}
+
+// GetThread returns all messages in the thread containing messageID, oldest first
+func (sm *SessionManager) GetThread(messageID string) ([]Message, error) {
+	messages, err := sm.allMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Message)
+	children := make(map[string][]string)
+	for _, msg := range messages {
+		byID[msg.ID] = msg
+		if msg.ParentID != "" {
+			children[msg.ParentID] = append(children[msg.ParentID], msg.ID)
+		}
+	}
+
+	if _, ok := byID[messageID]; !ok {
+		return nil, fmt.Errorf("message %s not found", messageID)
+	}
+
+	// Walk up to the root of the thread
+	root := messageID
+	for seen := map[string]bool{}; !seen[root]; {
+		seen[root] = true
+		parent := byID[root].ParentID
+		if _, ok := byID[parent]; !ok {
+			break
+		}
+		root = parent
+	}
+
+	// Collect the root and all its descendants
+	var thread []Message
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		thread = append(thread, byID[id])
+		queue = append(queue, children[id]...)
+	}
+
+	sort.SliceStable(thread, func(i, j int) bool {
+		return thread[i].Timestamp.Before(thread[j].Timestamp)
+	})
+	return thread, nil
+}
+
+// renderMessage formats a message as an instructions.md section
+func renderMessage(msg *Message) string {
+	var b strings.Builder
+
+	timestamp := msg.Timestamp.Format("2006-01-02 15:04:05")
+	b.WriteString(fmt.Sprintf("\n\n---\n## Instructions from %s (%s)\n", msg.From, timestamp))
+
+	meta := fmt.Sprintf("message: %s, type: %s", msg.ID, msg.Type)
+	if msg.To == "" {
+		meta += ", broadcast"
+	}
+	if msg.ParentID != "" {
+		meta += ", in reply to: " + msg.ParentID
+	}
+	b.WriteString(fmt.Sprintf("<!-- %s -->\n", meta))
+
+	if msg.Subject != "" {
+		b.WriteString(fmt.Sprintf("**Subject**: %s\n", msg.Subject))
+	}
+	b.WriteString(fmt.Sprintf("\n%s\n", msg.Content))
+
+	if len(msg.Attachments) > 0 {
+		b.WriteString("\nAttachments:\n")
+		for _, attachment := range msg.Attachments {
+			b.WriteString(fmt.Sprintf("- %s\n", attachment))
+		}
+	}
+
+	return b.String()
+}
+
+// readMessages reads a JSONL message file, returning nothing if it does not exist
+func readMessages(path string) ([]Message, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var messages []Message
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue // Skip partially written lines
+		}
+		messages = append(messages, msg)
+	}
+	return messages, scanner.Err()
+}
+
+// appendJSONLine appends a value as a single JSON line, creating the file if needed
+func appendJSONLine(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return appendFile(path, string(data)+"\n")
+}
+
+// appendFile appends content to a file in a single write, creating parent directories
+func appendFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	return err
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBroadcastOnlyReachesRunningSessions(t *testing.T) {
+	sm := newTestManager(t)
+	manager := createTestSession(t, sm, SessionTypeEngineeringManager)
+	early := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	if err := sm.SendMessage(&Message{From: manager.ID, Content: "Code freeze at 17:00"}); err != nil {
+		t.Fatal(err)
+	}
+	late := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	inbox, err := sm.ReadInbox(early.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox) != 1 || inbox[0].Content != "Code freeze at 17:00" {
+		t.Errorf("inbox of a running session = %+v", inbox)
+	}
+
+	if inbox, _ := sm.ReadInbox(late.ID, false); len(inbox) != 0 {
+		t.Errorf("session started after the broadcast got %+v", inbox)
+	}
+	if inbox, _ := sm.ReadInbox(manager.ID, false); len(inbox) != 0 {
+		t.Errorf("sender got its own broadcast: %+v", inbox)
+	}
+}
+
+func TestMessageIDsAndAcks(t *testing.T) {
+	sm := newTestManager(t)
+	from := createTestSession(t, sm, SessionTypeEngineeringManager)
+	to := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	first := &Message{From: from.ID, To: to.ID, Content: "first"}
+	second := &Message{From: from.ID, To: to.ID, Content: "second"}
+	for _, msg := range []*Message{first, second} {
+		if err := sm.SendMessage(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.HasPrefix(first.ID, "msg-") || first.ID == second.ID {
+		t.Errorf("message IDs %q and %q", first.ID, second.ID)
+	}
+
+	if err := sm.Ack(to.ID, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	unread, err := sm.ReadInbox(to.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unread) != 1 || unread[0].ID != second.ID {
+		t.Errorf("unread = %+v", unread)
+	}
+}
+
+func TestReplyThreadsUnderParent(t *testing.T) {
+	sm := newTestManager(t)
+	manager := createTestSession(t, sm, SessionTypeEngineeringManager)
+	eng := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	question := &Message{From: eng.ID, To: manager.ID, Type: MessageTypeQuestion, Subject: "Auth", Content: "JWT or sessions?"}
+	if err := sm.SendMessage(question); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := sm.Reply(question.ID, manager.ID, "", "JWT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.To != eng.ID || reply.Type != MessageTypeResponse || reply.Subject != "Re: Auth" || reply.ParentID != question.ID {
+		t.Errorf("reply = %+v", reply)
+	}
+
+	thread, err := sm.GetThread(reply.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(thread) != 2 || thread[0].ID != question.ID || thread[1].ID != reply.ID {
+		t.Errorf("thread = %+v", thread)
+	}
+
+	if _, err := sm.Reply("msg-unknown", manager.ID, "", "hello"); err == nil {
+		t.Error("replied to a message that does not exist")
+	}
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Priority levels for tasks and spawn requests. Higher values go first; they
+// share a scale with the persona priorities in limits.priorities.
+const (
+	PriorityLow    = -1
+	PriorityNormal = 0
+	PriorityHigh   = 1
+	PriorityUrgent = 2
+)
+
+var priorityNames = map[string]int{
+	"low":    PriorityLow,
+	"normal": PriorityNormal,
+	"medium": PriorityNormal,
+	"high":   PriorityHigh,
+	"urgent": PriorityUrgent,
+}
+
+// ParsePriority parses a priority name (low, normal, high, urgent) or number
+func ParsePriority(value string) (int, error) {
+	value = strings.ToLower(strings.Trim(strings.TrimSpace(value), "*_`\"'"))
+	if p, ok := priorityNames[value]; ok {
+		return p, nil
+	}
+	if p, err := strconv.Atoi(value); err == nil {
+		return p, nil
+	}
+	return 0, fmt.Errorf("invalid priority '%s' (use low, normal, high, urgent or a number)", value)
+}
+
+// PriorityName returns the name of a priority level, or its number
+func PriorityName(p int) string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityUrgent:
+		return "urgent"
+	}
+	return strconv.Itoa(p)
+}
+
+// Shorter deadline formats; a deadline given as a day is due at the end of it
+const (
+	deadlineMinuteFormat = "2006-01-02 15:04"
+	deadlineDateFormat   = "2006-01-02"
+)
+
+// ParseDeadline parses a deadline in tasks.md format (seconds optional), RFC
+// 3339 or as a date. When base is set, a duration such as "4h" is also accepted
+// and counted from base.
+func ParseDeadline(value string, base time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t := parseTaskTime(value); !t.IsZero() {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(deadlineMinuteFormat, value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(deadlineDateFormat, value, time.Local); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	if !base.IsZero() {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return base.Add(d), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid deadline '%s' (use %s, %s or RFC 3339)", value, TaskTimeFormat, deadlineDateFormat)
+}
+
+// FormatDue describes a deadline relative to now, e.g. "in 45m" or "2h ago"
+func FormatDue(deadline, now time.Time) string {
+	d := deadline.Sub(now)
+	if d >= 0 {
+		return "in " + formatDueDuration(d)
+	}
+	return formatDueDuration(-d) + " ago"
+}
+
+func formatDueDuration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// PriorityValue returns the task's priority level, normal when unset
+func (t Task) PriorityValue() int {
+	if p, err := ParsePriority(t.Priority); err == nil {
+		return p
+	}
+	return PriorityNormal
+}
+
+// Overdue reports whether an unfinished task is past its deadline
+func (t Task) Overdue(now time.Time) bool {
+	return !t.Deadline.IsZero() && t.Status != TaskStatusCompleted && now.After(t.Deadline)
+}
+
+// Overdue reports whether a running session is past the deadline of its request
+func (s *Session) Overdue(now time.Time) bool {
+	return !s.Deadline.IsZero() && s.Running() && now.After(s.Deadline)
+}
+
+// CountOverdue returns the number of unfinished tasks past their deadline
+func (tl *TaskList) CountOverdue(now time.Time) int {
+	count := 0
+	for _, t := range tl.Tasks {
+		if t.Overdue(now) {
+			count++
+		}
+	}
+	return count
+}
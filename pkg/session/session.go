@@ -31,23 +31,46 @@ const (
 // Session represents a persona's active session
 type Session struct {
 	ID              string      `json:"id"`
-	ParentSessionID string      `json:"parent_session_id,omitempty"` // Parent session directory ID (e.g., "abc123ef")
+	ParentSessionID string      `json:"parent_session_id,omitempty"` // Session that requested this one (empty for sessions started with the team)
 	PersonaType     SessionType `json:"persona_type"`
 	PersonaName     string      `json:"persona_name"`
 	StartTime       time.Time   `json:"start_time"`
-	Status          string      `json:"status"` // active, completed, failed
+	Status          string      `json:"status"` // active, restarting, resuming, stopped, completed, failed
 	WorkspaceID     string      `json:"workspace_id"`
 	PID             int         `json:"pid,omitempty"`
 	CurrentWork     string      `json:"current_work,omitempty"`     // One-liner status updated by worker
 	TmuxSession     string      `json:"tmux_session,omitempty"`     // Tmux session name
 	TmuxSpawned     bool        `json:"tmux_spawned"`               // Whether tmux session is spawned
 	TmuxAttachCmd   string      `json:"tmux_attach_cmd,omitempty"`  // Command to attach to tmux session
+	Backend         string      `json:"backend,omitempty"`          // Process backend that runs the worker (tmux, process)
+	// Restart tracking
+	RestartCount    int         `json:"restart_count,omitempty"`    // Times the worker has been restarted
+	LastExitReason  string      `json:"last_exit_reason,omitempty"` // Why the worker last exited
+	LastExitAt      time.Time   `json:"last_exit_at,omitempty"`     // When the worker last exited
+	LastRestart     time.Time   `json:"last_restart,omitempty"`     // When the worker was last restarted
 	// Token usage tracking
 	InputTokens     int64       `json:"input_tokens,omitempty"`     // Total input tokens used
 	OutputTokens    int64       `json:"output_tokens,omitempty"`    // Total output tokens used
-	TotalTokens     int64       `json:"total_tokens,omitempty"`     // Total tokens (input + output)
+	CacheReadTokens int64       `json:"cache_read_tokens,omitempty"` // Total prompt cache read tokens
+	CacheWriteTokens int64      `json:"cache_write_tokens,omitempty"` // Total prompt cache write tokens
+	TotalTokens     int64       `json:"total_tokens,omitempty"`     // Total tokens (input + output + cache)
 	EstimatedCost   float64     `json:"estimated_cost,omitempty"`   // Estimated cost in USD
 	Model           string      `json:"model,omitempty"`            // Model used (sonnet, opus, haiku)
+	// Worktree isolation
+	Branch          string      `json:"branch,omitempty"`           // Git branch the agent works on
+	WorktreePath    string      `json:"worktree_path,omitempty"`    // Git worktree the agent runs in
+	MergeStatus     string      `json:"merge_status,omitempty"`     // Result of merging the branch (merged, conflict, verify-failed, error)
+	MergeDetail     string      `json:"merge_detail,omitempty"`     // One-line summary of the last merge attempt
+	ReopenedAt      time.Time   `json:"reopened_at,omitempty"`      // When a failed gate or merge last reopened a task
+	SignedOffAt     time.Time   `json:"signed_off_at,omitempty"`    // When a person signed off the work with "wildwest signoff"
+	SignedOffBy     string      `json:"signed_off_by,omitempty"`    // Who signed off the work
+	// Spawn request metadata (request.yaml)
+	Priority        int         `json:"priority,omitempty"`         // Request priority (see ParsePriority)
+	Deadline        time.Time   `json:"deadline,omitempty"`         // When the requested work is due
+	RequestedModel  string      `json:"requested_model,omitempty"`  // Model override from the request
+	BudgetUSD       float64     `json:"budget_usd,omitempty"`       // Session budget from the request, in USD
+	BudgetTokens    int64       `json:"budget_tokens,omitempty"`    // Session budget from the request, in tokens
+	Labels          []string    `json:"labels,omitempty"`
 }
 
 // Workspace manages the shared database directory
@@ -57,8 +80,12 @@ type Workspace struct {
 	CreatedAt    time.Time `json:"created_at"`
 	Description  string    `json:"description"`
 	ActiveTasks  []string  `json:"active_tasks"`
+	Isolation    string    `json:"isolation,omitempty"` // How coding agents are isolated ("worktree" or empty)
 }
 
+// IsolationWorktree gives each coding agent its own git worktree and branch
+const IsolationWorktree = "worktree"
+
 // Message represents communication between personas
 type Message struct {
 	ID           string      `json:"id"`
@@ -89,8 +116,13 @@ type Task struct {
 	Description string     `json:"description"`
 	Status      TaskStatus `json:"status"`
 	AssignedBy  string     `json:"assigned_by,omitempty"`
+	AssignedTo  string     `json:"assigned_to,omitempty"` // Session that owns a shared board task
+	DependsOn   []string   `json:"depends_on,omitempty"` // Task references: "T2" or "<session-id|persona-type|name>/T2"
+	Priority    string     `json:"priority,omitempty"`   // low, normal, high, urgent
+	Deadline    time.Time  `json:"deadline,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	Notes       string     `json:"notes,omitempty"` // Free-form lines kept from tasks.md
 }
 
 // ReadTracker tracks what has been read from instructions.md and tasks.md
@@ -107,6 +139,7 @@ type ReadTracker struct {
 type SessionManager struct {
 	workspacePath string
 	nameGen       *names.NameGenerator
+	pricing       *PricingTable
 }
 
 // NewSessionManager creates a new session manager
@@ -130,6 +163,7 @@ func NewSessionManager(workspacePath string) (*SessionManager, error) {
 	sm := &SessionManager{
 		workspacePath: workspacePath,
 		nameGen:       names.NewNameGenerator(),
+		pricing:       DefaultPricing(),
 	}
 
 	// Load existing sessions and mark names as used
@@ -141,6 +175,24 @@ func NewSessionManager(workspacePath string) (*SessionManager, error) {
 	return sm, nil
 }
 
+// OpenSessionManager opens an existing workspace for reading without creating
+// any directories in it, for callers such as reports that only inspect it
+func OpenSessionManager(workspacePath string) (*SessionManager, error) {
+	info, err := os.Stat(workspacePath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", workspacePath)
+	}
+
+	return &SessionManager{
+		workspacePath: workspacePath,
+		nameGen:       names.NewNameGenerator(),
+		pricing:       DefaultPricing(),
+	}, nil
+}
+
 // loadExistingNames loads existing session names to avoid duplicates
 func (sm *SessionManager) loadExistingNames() error {
 	sessions, err := sm.GetAllSessions()
@@ -155,8 +207,9 @@ func (sm *SessionManager) loadExistingNames() error {
 	return nil
 }
 
-// CreateSession creates a new session for a persona
-func (sm *SessionManager) CreateSession(personaType SessionType, personaName string, workspaceID string) (*Session, error) {
+// CreateSession creates a new session for a persona. parentSessionID names the
+// session that requested it, if any.
+func (sm *SessionManager) CreateSession(personaType SessionType, personaName string, workspaceID string, parentSessionID string) (*Session, error) {
 	// Check singleton constraint
 	if personaType == SessionTypeProjectManager || personaType == SessionTypeEngineeringManager || personaType == SessionTypeSolutionsArchitect {
 		active, err := sm.GetActiveSessions()
@@ -177,9 +230,6 @@ func (sm *SessionManager) CreateSession(personaType SessionType, personaName str
 		personaName = sm.nameGen.GetNameForPersona(string(personaType))
 	}
 
-	// Extract parent session ID from workspace path
-	parentSessionID := filepath.Base(sm.workspacePath)
-
 	session := &Session{
 		ID:              fmt.Sprintf("%s-%d", personaType, time.Now().UnixNano()/1000000), // Use milliseconds for uniqueness
 		ParentSessionID: parentSessionID,
@@ -199,8 +249,8 @@ func (sm *SessionManager) CreateSession(personaType SessionType, personaName str
 
 	// Initialize tasks.md
 	tasksPath := filepath.Join(personaDir, "tasks.md")
-	initialTasks := "# Tasks\n\nNo tasks assigned yet.\n"
-	if err := os.WriteFile(tasksPath, []byte(initialTasks), 0644); err != nil {
+	initialTasks := "# Tasks\n\n" + noTasksPlaceholder + "\n"
+	if err := WriteFileAtomic(tasksPath, []byte(initialTasks), 0644); err != nil {
 		return nil, fmt.Errorf("failed to create tasks.md: %w", err)
 	}
 
@@ -229,6 +279,75 @@ func (sm *SessionManager) getPersonaDir(sessionID string) string {
 	return filepath.Join(sm.workspacePath, sessionID)
 }
 
+// sessionDataDir returns the directory holding a session's files: its persona
+// directory, or the directory it was moved to when it was completed or archived
+func (sm *SessionManager) sessionDataDir(sessionID string) string {
+	dir := sm.getPersonaDir(sessionID)
+	if _, err := os.Stat(dir); err == nil {
+		return dir
+	}
+	for _, suffix := range []string{"-completed", "-archived"} {
+		if _, err := os.Stat(dir + suffix); err == nil {
+			return dir + suffix
+		}
+	}
+	return dir
+}
+
+// GetSession loads a single session by ID
+func (sm *SessionManager) GetSession(sessionID string) (*Session, error) {
+	return readSession(filepath.Join(sm.getPersonaDir(sessionID), "session.json"))
+}
+
+// readSession loads a session.json file
+func readSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ResolveTargets finds the sessions a message target refers to.
+// The target may be a session ID, a persona type (all active sessions of that type)
+// or an assigned persona name (case-insensitive).
+func (sm *SessionManager) ResolveTargets(target string) ([]*Session, error) {
+	if sess, err := sm.GetSession(target); err == nil {
+		return []*Session{sess}, nil
+	}
+
+	sessions, err := sm.GetActiveSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Session
+	for _, sess := range sessions {
+		if string(sess.PersonaType) == target {
+			matches = append(matches, sess)
+		}
+	}
+	if len(matches) > 0 {
+		return matches, nil
+	}
+
+	for _, sess := range sessions {
+		if strings.EqualFold(sess.PersonaName, target) {
+			matches = append(matches, sess)
+		}
+	}
+	if len(matches) > 0 {
+		return matches, nil
+	}
+
+	return nil, fmt.Errorf("no active session matches %q (use a session ID, persona type or name)", target)
+}
+
 // GetActiveSessions returns all active sessions
 func (sm *SessionManager) GetActiveSessions() ([]*Session, error) {
 	entries, err := os.ReadDir(sm.workspacePath)
@@ -296,81 +415,134 @@ func (sm *SessionManager) GetAllSessions() ([]*Session, error) {
 	return sessions, nil
 }
 
-// UpdateSessionStatus updates the status of a session
-func (sm *SessionManager) UpdateSessionStatus(sessionID string, status string) error {
-	sessionPath := filepath.Join(sm.workspacePath, sessionID, "session.json")
-
-	data, err := os.ReadFile(sessionPath)
+// GetSessionHistory returns every session in the workspace, including completed
+// and archived ones
+func (sm *SessionManager) GetSessionHistory() ([]*Session, error) {
+	entries, err := os.ReadDir(sm.workspacePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var session Session
-	if err := json.Unmarshal(data, &session); err != nil {
-		return err
+	var sessions []*Session
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "shared" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(sm.workspacePath, entry.Name(), "session.json"))
+		if err != nil {
+			continue
+		}
+
+		var session Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			continue
+		}
+
+		sessions = append(sessions, &session)
 	}
 
-	session.Status = status
-	return sm.saveSession(&session)
+	return sessions, nil
 }
 
-// UpdateCurrentWork updates the current work status for a session
-func (sm *SessionManager) UpdateCurrentWork(sessionID string, currentWork string) error {
-	sessionPath := filepath.Join(sm.workspacePath, sessionID, "session.json")
+// UpdateSession loads a session, applies update and saves it under the workspace
+// lock. Completed and archived sessions are updated where they were moved to.
+func (sm *SessionManager) UpdateSession(sessionID string, update func(*Session)) error {
+	return sm.withLock(func() error {
+		sessionPath := filepath.Join(sm.sessionDataDir(sessionID), "session.json")
+		session, err := readSession(sessionPath)
+		if err != nil {
+			return err
+		}
 
-	data, err := os.ReadFile(sessionPath)
-	if err != nil {
-		return err
-	}
+		update(session)
+		return writeSession(sessionPath, session)
+	})
+}
 
-	var session Session
-	if err := json.Unmarshal(data, &session); err != nil {
-		return err
-	}
+// UpdateSessionStatus updates the status of a session
+func (sm *SessionManager) UpdateSessionStatus(sessionID string, status string) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.Status = status
+	})
+}
 
-	session.CurrentWork = currentWork
-	return sm.saveSession(&session)
+// UpdateCurrentWork updates the current work status for a session
+func (sm *SessionManager) UpdateCurrentWork(sessionID string, currentWork string) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.CurrentWork = currentWork
+	})
 }
 
 // UpdateTmuxSession updates the tmux session information for a session
 func (sm *SessionManager) UpdateTmuxSession(sessionID string, tmuxSession string, spawned bool) error {
-	sessionPath := filepath.Join(sm.workspacePath, sessionID, "session.json")
+	return sm.UpdateWorkerProcess(sessionID, "tmux", tmuxSession, fmt.Sprintf("tmux attach -t %s", tmuxSession), spawned)
+}
 
-	data, err := os.ReadFile(sessionPath)
-	if err != nil {
-		return err
-	}
+// UpdateWorkerProcess records which backend runs a session's worker and how to attach to it
+func (sm *SessionManager) UpdateWorkerProcess(sessionID, backendName, processName, attachCmd string, spawned bool) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.TmuxSession = processName
+		s.TmuxSpawned = spawned
+		s.TmuxAttachCmd = attachCmd
+		s.Backend = backendName
+	})
+}
 
-	var session Session
-	if err := json.Unmarshal(data, &session); err != nil {
-		return err
-	}
+// RecordExit records that a session's worker exited and sets its new status
+func (sm *SessionManager) RecordExit(sessionID, reason, status string) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.Status = status
+		s.LastExitReason = reason
+		s.LastExitAt = time.Now()
+	})
+}
 
-	session.TmuxSession = tmuxSession
-	session.TmuxSpawned = spawned
-	session.TmuxAttachCmd = fmt.Sprintf("tmux attach -t %s", tmuxSession)
-	return sm.saveSession(&session)
+// RecordSignoff records that a person signed off a session's work
+func (sm *SessionManager) RecordSignoff(sessionID, by string) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.SignedOffAt = time.Now()
+		s.SignedOffBy = by
+	})
 }
 
-// WriteInstructions writes instructions for a target persona
-func (sm *SessionManager) WriteInstructions(fromSessionID, toSessionID, instructions string) error {
-	targetDir := sm.getPersonaDir(toSessionID)
-	instructionsPath := filepath.Join(targetDir, "instructions.md")
+// RecordRestart marks a session active again after its crashed worker was respawned
+func (sm *SessionManager) RecordRestart(sessionID string) error {
+	return sm.UpdateSession(sessionID, func(s *Session) {
+		s.Status = "active"
+		s.RestartCount++
+		s.LastRestart = time.Now()
+	})
+}
 
-	// Read existing instructions if any
-	var existingInstructions string
-	if data, err := os.ReadFile(instructionsPath); err == nil {
-		existingInstructions = string(data)
-	}
+// RecordResume marks a session active again after its worker was respawned to
+// act on new work. Unlike a restart it does not count against the restart policy.
+func (sm *SessionManager) RecordResume(sessionID string) error {
+	return sm.UpdateSessionStatus(sessionID, "active")
+}
 
-	// Append new instructions with timestamp and source
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	newInstructions := fmt.Sprintf("\n\n---\n## Instructions from %s (%s)\n\n%s\n",
-		fromSessionID, timestamp, instructions)
+// Running reports whether a session has a worker or is about to get one again
+func (s *Session) Running() bool {
+	return s.Status == "active" || s.Status == "restarting" || s.Status == "resuming"
+}
 
-	content := existingInstructions + newInstructions
+// WriteInstructions sends task instructions to a target persona through the message store
+func (sm *SessionManager) WriteInstructions(fromSessionID, toSessionID, instructions string) error {
+	return sm.SendMessage(&Message{
+		From:    fromSessionID,
+		To:      toSessionID,
+		Type:    MessageTypeTask,
+		Content: instructions,
+	})
+}
 
-	return os.WriteFile(instructionsPath, []byte(content), 0644)
+// BroadcastInstructions sends instructions to every active session except the sender
+func (sm *SessionManager) BroadcastInstructions(fromSessionID, instructions string) error {
+	return sm.SendMessage(&Message{
+		From:    fromSessionID,
+		Type:    MessageTypeTask,
+		Content: instructions,
+	})
 }
 
 // ReadTasks reads the tasks.md file for a persona
@@ -385,28 +557,61 @@ func (sm *SessionManager) ReadTasks(sessionID string) (string, error) {
 
 // UpdateTasks updates the tasks.md file for a persona
 func (sm *SessionManager) UpdateTasks(sessionID string, tasks string) error {
-	tasksPath := filepath.Join(sm.getPersonaDir(sessionID), "tasks.md")
-	return os.WriteFile(tasksPath, []byte(tasks), 0644)
+	return sm.withLock(func() error {
+		return sm.writeTasks(sessionID, tasks)
+	})
 }
 
-// AddTask adds a new task to a persona's task list
-func (sm *SessionManager) AddTask(sessionID string, description string, assignedBy string) error {
+// writeTasks writes tasks.md; callers must hold the workspace lock
+func (sm *SessionManager) writeTasks(sessionID string, tasks string) error {
 	tasksPath := filepath.Join(sm.getPersonaDir(sessionID), "tasks.md")
+	return WriteFileAtomic(tasksPath, []byte(tasks), 0644)
+}
+
+// modifyTasks loads a persona's task list, applies modify and saves it under the workspace lock
+func (sm *SessionManager) modifyTasks(sessionID string, modify func(*TaskList) error) error {
+	return sm.withLock(func() error {
+		tl, err := sm.LoadTasks(sessionID)
+		if err != nil {
+			return err
+		}
+		if err := modify(tl); err != nil {
+			return err
+		}
+		return sm.writeTasks(sessionID, tl.String())
+	})
+}
 
-	// Read existing tasks
-	var existingTasks string
-	if data, err := os.ReadFile(tasksPath); err == nil {
-		existingTasks = string(data)
+// LoadTasks reads and parses the tasks.md file for a persona
+func (sm *SessionManager) LoadTasks(sessionID string) (*TaskList, error) {
+	tasks, err := sm.ReadTasks(sessionID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &TaskList{Preamble: "# Tasks"}, nil
+		}
+		return nil, err
 	}
+	return ParseTasks(tasks), nil
+}
 
-	// Add new task
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	newTask := fmt.Sprintf("\n## Task: %s\n- **Status**: not started\n- **Assigned by**: %s\n- **Created**: %s\n",
-		description, assignedBy, timestamp)
+// SaveTasks writes a task list to the tasks.md file for a persona
+func (sm *SessionManager) SaveTasks(sessionID string, tl *TaskList) error {
+	return sm.UpdateTasks(sessionID, tl.String())
+}
 
-	content := existingTasks + newTask
+// SetTaskStatus updates the status of a single task in a persona's task list
+func (sm *SessionManager) SetTaskStatus(sessionID, taskID string, status TaskStatus) error {
+	return sm.modifyTasks(sessionID, func(tl *TaskList) error {
+		return tl.SetStatus(taskID, status)
+	})
+}
 
-	return os.WriteFile(tasksPath, []byte(content), 0644)
+// AddTask adds a new task to a persona's task list
+func (sm *SessionManager) AddTask(sessionID string, description string, assignedBy string) error {
+	return sm.modifyTasks(sessionID, func(tl *TaskList) error {
+		tl.Add(description, assignedBy)
+		return nil
+	})
 }
 
 // ReadInstructions reads instructions for a persona
@@ -425,7 +630,7 @@ func (sm *SessionManager) ReadInstructions(sessionID string) (string, error) {
 // WriteOutput writes output for a session
 func (sm *SessionManager) WriteOutput(sessionID string, filename string, content string) error {
 	outputPath := filepath.Join(sm.getPersonaDir(sessionID), filename)
-	return os.WriteFile(outputPath, []byte(content), 0644)
+	return WriteFileAtomic(outputPath, []byte(content), 0644)
 }
 
 // ReadOutput reads an output file from a session
@@ -469,18 +674,32 @@ func (sm *SessionManager) ReadSharedFile(filename string) (string, error) {
 // WriteSharedFile writes a file to the shared directory
 func (sm *SessionManager) WriteSharedFile(filename string, content string) error {
 	path := filepath.Join(sm.workspacePath, "shared", filename)
-	return os.WriteFile(path, []byte(content), 0644)
+	return WriteFileAtomic(path, []byte(content), 0644)
 }
 
-// saveSession saves a session to disk
+// saveSession saves a session to disk atomically; read-modify-write callers
+// must hold the workspace lock
 func (sm *SessionManager) saveSession(session *Session) error {
+	return writeSession(filepath.Join(sm.workspacePath, session.ID, "session.json"), session)
+}
+
+// writeSession writes a session.json file atomically
+func writeSession(path string, session *Session) error {
 	data, err := json.MarshalIndent(session, "", "  ")
 	if err != nil {
 		return err
 	}
+	return WriteFileAtomic(path, data, 0644)
+}
+
+// SetPricing sets the pricing table used to cost token usage
+func (sm *SessionManager) SetPricing(pricing *PricingTable) {
+	sm.pricing = pricing
+}
 
-	sessionPath := filepath.Join(sm.workspacePath, session.ID, "session.json")
-	return os.WriteFile(sessionPath, data, 0644)
+// Pricing returns the pricing table used to cost token usage
+func (sm *SessionManager) Pricing() *PricingTable {
+	return sm.pricing
 }
 
 // GetWorkspacePath returns the workspace path
@@ -521,11 +740,23 @@ func (sm *SessionManager) saveTracker(sessionID string, tracker *ReadTracker) er
 	}
 
 	trackerPath := filepath.Join(sm.getPersonaDir(sessionID), "tracker.json")
-	return os.WriteFile(trackerPath, data, 0644)
+	return WriteFileAtomic(trackerPath, data, 0644)
 }
 
 // GetNewInstructions returns only new instructions since last read
 func (sm *SessionManager) GetNewInstructions(sessionID string) (string, error) {
+	var content string
+	err := sm.withLock(func() error {
+		var err error
+		content, err = sm.readNewInstructions(sessionID)
+		return err
+	})
+	return content, err
+}
+
+// readNewInstructions reads instructions past the tracker position and advances it;
+// callers must hold the workspace lock
+func (sm *SessionManager) readNewInstructions(sessionID string) (string, error) {
 	tracker, err := sm.GetTracker(sessionID)
 	if err != nil {
 		return "", err
@@ -614,8 +845,10 @@ func (sm *SessionManager) CheckForUpdates(sessionID string) (bool, string, error
 	}
 
 	// Update last check time
-	tracker.LastCheckTime = time.Now()
-	sm.saveTracker(sessionID, tracker)
+	sm.withLock(func() error {
+		tracker.LastCheckTime = time.Now()
+		return sm.saveTracker(sessionID, tracker)
+	})
 
 	return hasUpdates, strings.Join(updates, ", "), nil
 }
@@ -636,13 +869,44 @@ func (sm *SessionManager) CreateWorkspace(description string) (*Workspace, error
 	}
 
 	workspacePath := filepath.Join(sm.workspacePath, "workspace.json")
-	if err := os.WriteFile(workspacePath, data, 0644); err != nil {
+	if err := WriteFileAtomic(workspacePath, data, 0644); err != nil {
 		return nil, err
 	}
 
 	return workspace, nil
 }
 
+// GetWorkspace loads the workspace metadata
+func (sm *SessionManager) GetWorkspace() (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Join(sm.workspacePath, "workspace.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace Workspace
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// SetIsolation records how coding agents in the workspace are isolated
+func (sm *SessionManager) SetIsolation(mode string) error {
+	return sm.withLock(func() error {
+		workspace, err := sm.GetWorkspace()
+		if err != nil {
+			return fmt.Errorf("failed to load workspace: %w", err)
+		}
+		workspace.Isolation = mode
+
+		data, err := json.MarshalIndent(workspace, "", "  ")
+		if err != nil {
+			return err
+		}
+		return WriteFileAtomic(filepath.Join(sm.workspacePath, "workspace.json"), data, 0644)
+	})
+}
+
 // GetCurrentWork generates an intelligent summary of what the team member is working on
 func (sm *SessionManager) GetCurrentWork(sessionID string) string {
 	personaDir := sm.getPersonaDir(sessionID)
@@ -711,75 +975,28 @@ If tasks.md shows "in progress", focus on that task. If only "not started", say
 
 // getSimpleCurrentWork is a fallback that parses tasks.md directly
 func (sm *SessionManager) getSimpleCurrentWork(sessionID string) string {
-	tasksContent, err := sm.ReadTasks(sessionID)
+	tl, err := sm.LoadTasks(sessionID)
 	if err != nil {
 		return "No tasks found"
 	}
 
-	// Parse tasks.md to find current work
-	lines := strings.Split(tasksContent, "\n")
-	var currentTask string
-	var currentStatus string
-	inTask := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Check for task header
-		if strings.HasPrefix(trimmed, "## Task:") {
-			currentTask = strings.TrimPrefix(trimmed, "## Task:")
-			currentTask = strings.TrimSpace(currentTask)
-			inTask = true
-			currentStatus = ""
-			continue
-		}
-
-		// Check for status line
-		if inTask && strings.HasPrefix(trimmed, "- **Status**:") {
-			currentStatus = strings.TrimPrefix(trimmed, "- **Status**:")
-			currentStatus = strings.TrimSpace(currentStatus)
-
-			// If status is "in progress", return this task immediately
-			if currentStatus == "in progress" {
-				if len(currentTask) > 80 {
-					return currentTask[:77] + "..."
-				}
-				return currentTask
-			}
+	// An "in progress" task is the current work
+	if t := tl.FirstWithStatus(TaskStatusInProgress); t != nil {
+		if len(t.Description) > 80 {
+			return t.Description[:77] + "..."
 		}
+		return t.Description
 	}
 
-	// If no "in progress" task found, look for first "not started" task
-	inTask = false
-	currentTask = ""
-	currentStatus = ""
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		if strings.HasPrefix(trimmed, "## Task:") {
-			currentTask = strings.TrimPrefix(trimmed, "## Task:")
-			currentTask = strings.TrimSpace(currentTask)
-			inTask = true
-			currentStatus = ""
-			continue
-		}
-
-		if inTask && strings.HasPrefix(trimmed, "- **Status**:") {
-			currentStatus = strings.TrimPrefix(trimmed, "- **Status**:")
-			currentStatus = strings.TrimSpace(currentStatus)
-
-			if currentStatus == "not started" {
-				if len(currentTask) > 80 {
-					return "Awaiting: " + currentTask[:72] + "..."
-				}
-				return "Awaiting: " + currentTask
-			}
+	// Otherwise the first "not started" task is up next
+	if t := tl.FirstWithStatus(TaskStatusNotStarted); t != nil {
+		if len(t.Description) > 80 {
+			return "Awaiting: " + t.Description[:72] + "..."
 		}
+		return "Awaiting: " + t.Description
 	}
 
-	// If all tasks are completed
-	if strings.Contains(tasksContent, "completed") {
+	if tl.AllCompleted() {
 		return "All tasks completed"
 	}
 
@@ -788,7 +1005,17 @@ func (sm *SessionManager) getSimpleCurrentWork(sessionID string) string {
 
 // GenerateSessionID creates a random 8-character session ID
 func GenerateSessionID() string {
-	bytes := make([]byte, 4)
+	return randomHex(4)
+}
+
+// GenerateMessageID creates a random message ID
+func GenerateMessageID() string {
+	return "msg-" + randomHex(8)
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) string {
+	bytes := make([]byte, n)
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
 }
@@ -804,7 +1031,7 @@ type SessionMetadata struct {
 // SaveSessionDescription saves the task description to description.txt
 func SaveSessionDescription(sessionPath, description string) error {
 	descPath := filepath.Join(sessionPath, "description.txt")
-	return os.WriteFile(descPath, []byte(description), 0644)
+	return WriteFileAtomic(descPath, []byte(description), 0644)
 }
 
 // LoadSessionDescription reads the task description from description.txt
@@ -0,0 +1,205 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpawnRequestsFile holds spawn requests waiting for a human decision
+const SpawnRequestsFile = "orchestrator/requests.json"
+
+// RequesterFile is an optional file in a request directory naming the session
+// that made the request
+const RequesterFile = "requester"
+
+// RequestMetaFile is an optional file in a request directory with spawn options
+const RequestMetaFile = "request.yaml"
+
+// RequestMeta is the content of a request directory's request.yaml:
+//
+//	priority: high            # low, normal, high, urgent or a number
+//	deadline: 2024-01-27 17:00 # or a date, RFC 3339, or a duration such as 4h
+//	model: opus
+//	budget:
+//	  usd: 5
+//	  tokens: 2000000
+//	requester: engineering-manager-1706012345678
+//	labels: [backend, auth]
+type RequestMeta struct {
+	Priority  string        `yaml:"priority,omitempty"`
+	Deadline  string        `yaml:"deadline,omitempty"`
+	Model     string        `yaml:"model,omitempty"`
+	Budget    RequestBudget `yaml:"budget,omitempty"`
+	Requester string        `yaml:"requester,omitempty"`
+	Labels    []string      `yaml:"labels,omitempty"`
+
+	modTime time.Time // When request.yaml was written; relative deadlines count from it
+}
+
+// RequestBudget caps the spend of the session spawned for a request
+type RequestBudget struct {
+	USD    float64 `yaml:"usd,omitempty"`
+	Tokens int64   `yaml:"tokens,omitempty"`
+}
+
+// LoadRequestMeta reads a request directory's request.yaml. A missing file
+// yields empty metadata; the requester file is used when request.yaml does not
+// name a requester. If request.yaml cannot be read, the error comes with
+// metadata holding just the requester file's requester.
+func LoadRequestMeta(requestDir string) (*RequestMeta, error) {
+	meta := &RequestMeta{}
+	path := filepath.Join(requestDir, RequestMetaFile)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(data, meta); err != nil {
+			return &RequestMeta{Requester: readRequesterFile(requestDir)}, fmt.Errorf("failed to parse %s: %w", RequestMetaFile, err)
+		}
+		if info, err := os.Stat(path); err == nil {
+			meta.modTime = info.ModTime()
+		}
+	} else if !os.IsNotExist(err) {
+		return &RequestMeta{Requester: readRequesterFile(requestDir)}, err
+	}
+
+	if meta.Requester == "" {
+		meta.Requester = readRequesterFile(requestDir)
+	}
+	return meta, nil
+}
+
+// PriorityValue returns the requested priority and whether one was given
+func (m *RequestMeta) PriorityValue() (int, bool, error) {
+	if strings.TrimSpace(m.Priority) == "" {
+		return 0, false, nil
+	}
+	p, err := ParsePriority(m.Priority)
+	return p, err == nil, err
+}
+
+// DeadlineTime returns the requested deadline, or zero time if none was given
+func (m *RequestMeta) DeadlineTime() (time.Time, error) {
+	if strings.TrimSpace(m.Deadline) == "" {
+		return time.Time{}, nil
+	}
+	base := m.modTime
+	if base.IsZero() {
+		base = time.Now()
+	}
+	return ParseDeadline(m.Deadline, base)
+}
+
+// Spawn request decisions
+const (
+	RequestPending  = "pending"
+	RequestApproved = "approved"
+	RequestDenied   = "denied"
+)
+
+// SpawnRequest is a *-request-* directory held for approval
+type SpawnRequest struct {
+	ID          string      `json:"id"` // Request directory name
+	PersonaType SessionType `json:"persona_type"`
+	Requester   string      `json:"requester,omitempty"` // Session ID of the requesting persona
+	Summary     string      `json:"summary,omitempty"`   // First line of the request's instructions
+	RequestedAt time.Time   `json:"requested_at"`
+	Status      string      `json:"status"` // pending, approved, denied
+	Reason      string      `json:"reason,omitempty"`
+	DecidedBy   string      `json:"decided_by,omitempty"`
+	DecidedAt   time.Time   `json:"decided_at,omitempty"`
+}
+
+// SpawnRequestsPath returns the path of the approval queue
+func (sm *SessionManager) SpawnRequestsPath() string {
+	return filepath.Join(sm.workspacePath, SpawnRequestsFile)
+}
+
+// LoadSpawnRequests returns the spawn requests in the approval queue, oldest first
+func (sm *SessionManager) LoadSpawnRequests() ([]*SpawnRequest, error) {
+	data, err := os.ReadFile(sm.SpawnRequestsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var requests []*SpawnRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SpawnRequestsFile, err)
+	}
+	return requests, nil
+}
+
+// UpdateSpawnRequests loads the approval queue, applies modify and saves the
+// result under the workspace lock
+func (sm *SessionManager) UpdateSpawnRequests(modify func([]*SpawnRequest) ([]*SpawnRequest, error)) error {
+	return sm.withLock(func() error {
+		requests, err := sm.LoadSpawnRequests()
+		if err != nil {
+			return err
+		}
+		requests, err = modify(requests)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(requests, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(sm.SpawnRequestsPath()), 0755); err != nil {
+			return err
+		}
+		return WriteFileAtomic(sm.SpawnRequestsPath(), data, 0644)
+	})
+}
+
+// DecideSpawnRequest approves or denies a pending request. The request is
+// matched by directory name or a unique part of it.
+func (sm *SessionManager) DecideSpawnRequest(id, status, reason, decidedBy string) (*SpawnRequest, error) {
+	var decided *SpawnRequest
+	err := sm.UpdateSpawnRequests(func(requests []*SpawnRequest) ([]*SpawnRequest, error) {
+		var matches []*SpawnRequest
+		for _, req := range requests {
+			if req.ID == id {
+				matches = []*SpawnRequest{req}
+				break
+			}
+			if strings.Contains(req.ID, id) {
+				matches = append(matches, req)
+			}
+		}
+		switch {
+		case len(matches) == 0:
+			return nil, fmt.Errorf("no spawn request matches '%s'", id)
+		case len(matches) > 1:
+			return nil, fmt.Errorf("'%s' matches %d spawn requests, use the full ID", id, len(matches))
+		}
+
+		decided = matches[0]
+		if decided.Status != RequestPending {
+			return nil, fmt.Errorf("spawn request %s is already %s", decided.ID, decided.Status)
+		}
+		decided.Status = status
+		decided.Reason = reason
+		decided.DecidedBy = decidedBy
+		decided.DecidedAt = time.Now()
+		return requests, nil
+	})
+	return decided, err
+}
+
+// readRequesterFile returns the content of a request directory's requester file
+func readRequesterFile(requestDir string) string {
+	data, err := os.ReadFile(filepath.Join(requestDir, RequesterFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,115 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeRequestFiles creates a request directory holding the given files
+func writeRequestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadRequestMeta(t *testing.T) {
+	dir := writeRequestFiles(t, map[string]string{
+		RequestMetaFile: `priority: high
+deadline: 2024-01-27 17:00
+model: opus
+budget:
+  usd: 5
+  tokens: 2000000
+requester: engineering-manager-1706012345678
+labels: [backend, auth]
+`,
+		RequesterFile: "solutions-architect-1706012345000\n",
+	})
+
+	meta, err := LoadRequestMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Model != "opus" || meta.Budget.USD != 5 || meta.Budget.Tokens != 2000000 {
+		t.Errorf("meta = %+v", meta)
+	}
+	if meta.Requester != "engineering-manager-1706012345678" {
+		t.Errorf("requester = %q, want the one from request.yaml", meta.Requester)
+	}
+	if !reflect.DeepEqual(meta.Labels, []string{"backend", "auth"}) {
+		t.Errorf("labels = %v", meta.Labels)
+	}
+	if p, ok, err := meta.PriorityValue(); err != nil || !ok || p != PriorityHigh {
+		t.Errorf("priority = %d, %v, %v; want high", p, ok, err)
+	}
+	want := time.Date(2024, 1, 27, 17, 0, 0, 0, time.Local)
+	if deadline, err := meta.DeadlineTime(); err != nil || !deadline.Equal(want) {
+		t.Errorf("deadline = %v, %v; want %v", deadline, err, want)
+	}
+}
+
+func TestLoadRequestMetaRelativeDeadline(t *testing.T) {
+	dir := writeRequestFiles(t, map[string]string{RequestMetaFile: "deadline: 4h\n"})
+	written := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(filepath.Join(dir, RequestMetaFile), written, written); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := LoadRequestMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline, err := meta.DeadlineTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := written.Add(4 * time.Hour); !deadline.Equal(want) {
+		t.Errorf("deadline = %v, want 4h after request.yaml was written (%v)", deadline, want)
+	}
+}
+
+func TestLoadRequestMetaFallsBackToRequesterFile(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"no request.yaml":      {RequesterFile: "qa-1706012345678\n"},
+		"no requester in yaml": {RequesterFile: "qa-1706012345678\n", RequestMetaFile: "priority: low\n"},
+	} {
+		meta, err := LoadRequestMeta(writeRequestFiles(t, files))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if meta.Requester != "qa-1706012345678" {
+			t.Errorf("%s: requester = %q, want the requester file's", name, meta.Requester)
+		}
+	}
+}
+
+func TestLoadRequestMetaSyntaxErrorKeepsRequester(t *testing.T) {
+	dir := writeRequestFiles(t, map[string]string{
+		RequestMetaFile: "priority: [high\n",
+		RequesterFile:   "qa-1706012345678\n",
+	})
+
+	meta, err := LoadRequestMeta(dir)
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if meta == nil || meta.Requester != "qa-1706012345678" || meta.Priority != "" {
+		t.Errorf("meta = %+v, want only the requester file's requester", meta)
+	}
+}
+
+func TestRequestMetaInvalidPriority(t *testing.T) {
+	meta := &RequestMeta{Priority: "soonish"}
+	if _, ok, err := meta.PriorityValue(); err == nil || ok {
+		t.Errorf("priority 'soonish' accepted (ok = %v, err = %v)", ok, err)
+	}
+}
@@ -0,0 +1,260 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tarzzz/wildwest/pkg/persona"
+)
+
+// TaskKey identifies a task across the workspace as "<session-id>/<task-id>"
+func TaskKey(sessionID, taskID string) string {
+	return sessionID + "/" + taskID
+}
+
+// TaskNode is a task in the workspace task graph
+type TaskNode struct {
+	Key         string
+	SessionID   string
+	PersonaName string
+	PersonaType SessionType
+	Task        Task
+	DependsOn   []string // Keys of the tasks this task depends on
+	Unresolved  []string // Dependency references that match no known task
+}
+
+// Done reports whether the task is completed
+func (n *TaskNode) Done() bool {
+	return n.Task.Status == TaskStatusCompleted
+}
+
+// TaskGraph links the tasks of every persona in a workspace by their
+// "Depends on" references
+type TaskGraph struct {
+	Nodes map[string]*TaskNode
+	Keys  []string // Node keys in session start order, then task order
+}
+
+// BuildTaskGraph loads the tasks of every session in the workspace, including
+// completed and archived ones, and resolves their dependencies
+func (sm *SessionManager) BuildTaskGraph() (*TaskGraph, error) {
+	entries, err := os.ReadDir(sm.workspacePath)
+	if err != nil {
+		return nil, err
+	}
+
+	type sessionTasks struct {
+		session *Session
+		tasks   *TaskList
+	}
+	var all []sessionTasks
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "shared" || strings.Contains(entry.Name(), persona.RequestInfix) {
+			continue
+		}
+		dir := filepath.Join(sm.workspacePath, entry.Name())
+
+		data, err := os.ReadFile(filepath.Join(dir, "session.json"))
+		if err != nil {
+			continue
+		}
+		var sess Session
+		if err := json.Unmarshal(data, &sess); err != nil {
+			continue
+		}
+
+		tasks := &TaskList{}
+		if content, err := os.ReadFile(filepath.Join(dir, "tasks.md")); err == nil {
+			tasks = ParseTasks(string(content))
+		}
+		all = append(all, sessionTasks{session: &sess, tasks: tasks})
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].session.StartTime.Before(all[j].session.StartTime)
+	})
+
+	graph := &TaskGraph{Nodes: make(map[string]*TaskNode)}
+	sessions := make([]*Session, 0, len(all))
+	for _, st := range all {
+		sessions = append(sessions, st.session)
+		for _, task := range st.tasks.Tasks {
+			key := TaskKey(st.session.ID, task.ID)
+			graph.Nodes[key] = &TaskNode{
+				Key:         key,
+				SessionID:   st.session.ID,
+				PersonaName: st.session.PersonaName,
+				PersonaType: st.session.PersonaType,
+				Task:        task,
+			}
+			graph.Keys = append(graph.Keys, key)
+		}
+	}
+
+	for _, key := range graph.Keys {
+		node := graph.Nodes[key]
+		for _, ref := range node.Task.DependsOn {
+			if depKey, ok := graph.resolve(ref, node.SessionID, sessions); ok {
+				node.DependsOn = append(node.DependsOn, depKey)
+			} else {
+				node.Unresolved = append(node.Unresolved, ref)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// resolve turns a dependency reference into a node key. A bare task ID refers
+// to the referencing session; otherwise the part before the last "/" is a
+// session ID, persona type or persona name.
+func (g *TaskGraph) resolve(ref, fromSession string, sessions []*Session) (string, bool) {
+	slash := strings.LastIndex(ref, "/")
+	if slash < 0 {
+		key := TaskKey(fromSession, ref)
+		if _, ok := g.Nodes[key]; ok || !IsBoardTask(ref) {
+			return key, ok
+		}
+
+		// Board task IDs are unique across the workspace
+		for _, k := range g.Keys {
+			if g.Nodes[k].Task.ID == ref {
+				return k, true
+			}
+		}
+		return "", false
+	}
+
+	target, taskID := ref[:slash], ref[slash+1:]
+	for _, sess := range sessions {
+		if sess.ID == target {
+			key := TaskKey(sess.ID, taskID)
+			_, ok := g.Nodes[key]
+			return key, ok
+		}
+	}
+
+	// Persona type or name must identify exactly one session with that task
+	var matches []string
+	for _, sess := range sessions {
+		if string(sess.PersonaType) != target && !strings.EqualFold(sess.PersonaName, target) {
+			continue
+		}
+		if _, ok := g.Nodes[TaskKey(sess.ID, taskID)]; ok {
+			matches = append(matches, TaskKey(sess.ID, taskID))
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], true
+	}
+	return "", false
+}
+
+// Blockers returns the unfinished dependencies of a task
+func (g *TaskGraph) Blockers(key string) []string {
+	node, ok := g.Nodes[key]
+	if !ok {
+		return nil
+	}
+
+	var blockers []string
+	for _, dep := range node.DependsOn {
+		if depNode := g.Nodes[dep]; depNode != nil && !depNode.Done() {
+			blockers = append(blockers, dep)
+		}
+	}
+	return blockers
+}
+
+// Blocked returns the keys of unfinished tasks waiting on unfinished dependencies
+func (g *TaskGraph) Blocked() []string {
+	var blocked []string
+	for _, key := range g.Keys {
+		if !g.Nodes[key].Done() && len(g.Blockers(key)) > 0 {
+			blocked = append(blocked, key)
+		}
+	}
+	return blocked
+}
+
+// Dependents returns the keys of tasks that depend on the given task
+func (g *TaskGraph) Dependents(key string) []string {
+	var dependents []string
+	for _, k := range g.Keys {
+		for _, dep := range g.Nodes[k].DependsOn {
+			if dep == key {
+				dependents = append(dependents, k)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// CriticalPath returns the longest chain of unfinished tasks, ordered from the
+// task that must be done first to the task finished last. Dependency cycles
+// are broken where they are found.
+func (g *TaskGraph) CriticalPath() []string {
+	memo := make(map[string][]string)
+	visiting := make(map[string]bool)
+
+	// longest returns the longest unfinished chain ending at key
+	var longest func(key string) []string
+	longest = func(key string) []string {
+		if path, ok := memo[key]; ok {
+			return path
+		}
+		node := g.Nodes[key]
+		if node == nil || node.Done() || visiting[key] {
+			return nil
+		}
+
+		visiting[key] = true
+		var best []string
+		for _, dep := range node.DependsOn {
+			if path := longest(dep); len(path) > len(best) {
+				best = path
+			}
+		}
+		visiting[key] = false
+
+		path := append(append([]string{}, best...), key)
+		memo[key] = path
+		return path
+	}
+
+	var critical []string
+	for _, key := range g.Keys {
+		if path := longest(key); len(path) > len(critical) {
+			critical = path
+		}
+	}
+	return critical
+}
+
+// Cycles returns the keys of tasks that depend on themselves, directly or
+// through other tasks
+func (g *TaskGraph) Cycles() []string {
+	var cyclic []string
+	for _, key := range g.Keys {
+		seen := make(map[string]bool)
+		stack := append([]string{}, g.Nodes[key].DependsOn...)
+		for len(stack) > 0 {
+			dep := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if dep == key {
+				cyclic = append(cyclic, key)
+				break
+			}
+			if seen[dep] || g.Nodes[dep] == nil {
+				continue
+			}
+			seen[dep] = true
+			stack = append(stack, g.Nodes[dep].DependsOn...)
+		}
+	}
+	return cyclic
+}
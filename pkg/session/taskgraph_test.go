@@ -0,0 +1,144 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTasks replaces a session's tasks.md
+func writeTasks(t *testing.T, sm *SessionManager, sessionID, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(sm.workspacePath, sessionID, "tasks.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func buildGraph(t *testing.T, sm *SessionManager) *TaskGraph {
+	t.Helper()
+	graph, err := sm.BuildTaskGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestTaskGraphResolvesReferences(t *testing.T) {
+	sm := newTestManager(t)
+	architect := createTestSession(t, sm, SessionTypeSolutionsArchitect)
+	engineer := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	qa := createTestSession(t, sm, SessionTypeQA)
+
+	writeTasks(t, sm, architect.ID, `## Task: Design the API
+- **ID**: T1
+- **Status**: pending
+
+## Task: Pick a database
+- **ID**: T2
+- **Status**: completed
+
+## Task: Shared board task
+- **ID**: B1
+- **Status**: pending
+`)
+	writeTasks(t, sm, engineer.ID, `## Task: Implement the API
+- **ID**: T1
+- **Status**: pending
+- **Depends on**: solutions-architect/T1, `+architect.ID+`/T2
+
+## Task: Wire up the database
+- **ID**: T2
+- **Status**: pending
+- **Depends on**: T1, B1
+
+## Task: Call a stranger
+- **ID**: T3
+- **Status**: pending
+- **Depends on**: nobody/T9, T7
+`)
+	writeTasks(t, sm, qa.ID, `## Task: Test the API
+- **ID**: T1
+- **Status**: pending
+- **Depends on**: `+engineer.PersonaName+`/T2
+`)
+	// Completed sessions stay in the graph
+	if err := os.Rename(filepath.Join(sm.workspacePath, qa.ID), filepath.Join(sm.workspacePath, qa.ID+"-completed")); err != nil {
+		t.Fatal(err)
+	}
+
+	graph := buildGraph(t, sm)
+	for key, want := range map[string][]string{
+		TaskKey(engineer.ID, "T1"): {TaskKey(architect.ID, "T1"), TaskKey(architect.ID, "T2")},
+		TaskKey(engineer.ID, "T2"): {TaskKey(engineer.ID, "T1"), TaskKey(architect.ID, "B1")},
+		TaskKey(qa.ID, "T1"):       {TaskKey(engineer.ID, "T2")},
+	} {
+		node := graph.Nodes[key]
+		if node == nil {
+			t.Fatalf("graph has no node %s", key)
+		}
+		if !reflect.DeepEqual(node.DependsOn, want) {
+			t.Errorf("%s depends on %v, want %v", key, node.DependsOn, want)
+		}
+	}
+	if got := graph.Nodes[TaskKey(engineer.ID, "T3")].Unresolved; !reflect.DeepEqual(got, []string{"nobody/T9", "T7"}) {
+		t.Errorf("unresolved = %v", got)
+	}
+
+	if got, want := graph.Blockers(TaskKey(engineer.ID, "T1")), []string{TaskKey(architect.ID, "T1")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("blockers = %v, want %v (completed dependencies do not block)", got, want)
+	}
+
+	want := []string{TaskKey(architect.ID, "T1"), TaskKey(engineer.ID, "T1"), TaskKey(engineer.ID, "T2"), TaskKey(qa.ID, "T1")}
+	if got := graph.CriticalPath(); !reflect.DeepEqual(got, want) {
+		t.Errorf("critical path = %v, want %v", got, want)
+	}
+	if cycles := graph.Cycles(); len(cycles) != 0 {
+		t.Errorf("cycles = %v, want none", cycles)
+	}
+}
+
+func TestTaskGraphAmbiguousPersonaTypeIsUnresolved(t *testing.T) {
+	sm := newTestManager(t)
+	first := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	second := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	qa := createTestSession(t, sm, SessionTypeQA)
+
+	task := "## Task: Build it\n- **ID**: T1\n- **Status**: pending\n"
+	writeTasks(t, sm, first.ID, task)
+	writeTasks(t, sm, second.ID, task)
+	writeTasks(t, sm, qa.ID, "## Task: Test it\n- **ID**: T1\n- **Depends on**: software-engineer/T1\n")
+
+	node := buildGraph(t, sm).Nodes[TaskKey(qa.ID, "T1")]
+	if len(node.DependsOn) != 0 || !reflect.DeepEqual(node.Unresolved, []string{"software-engineer/T1"}) {
+		t.Errorf("depends on %v, unresolved %v; want the reference unresolved", node.DependsOn, node.Unresolved)
+	}
+}
+
+func TestTaskGraphCycles(t *testing.T) {
+	sm := newTestManager(t)
+	sess := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+	writeTasks(t, sm, sess.ID, `## Task: One
+- **ID**: T1
+- **Depends on**: T2
+
+## Task: Two
+- **ID**: T2
+- **Depends on**: T1
+
+## Task: Three
+- **ID**: T3
+- **Depends on**: T1
+`)
+
+	graph := buildGraph(t, sm)
+	want := []string{TaskKey(sess.ID, "T1"), TaskKey(sess.ID, "T2")}
+	if got := graph.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("cycles = %v, want %v", got, want)
+	}
+
+	// The cycle is broken rather than followed forever
+	if path := graph.CriticalPath(); len(path) != 3 || path[len(path)-1] != TaskKey(sess.ID, "T3") {
+		t.Errorf("critical path = %v, want a chain of 3 ending at T3", path)
+	}
+}
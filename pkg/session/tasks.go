@@ -0,0 +1,324 @@
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskTimeFormat is the timestamp format used in tasks.md
+const TaskTimeFormat = "2006-01-02 15:04:05"
+
+// taskHeaderPrefix starts every task section in tasks.md
+const taskHeaderPrefix = "## Task:"
+
+// taskFieldPattern matches field lines such as "- **Status**: in progress"
+var taskFieldPattern = regexp.MustCompile(`^\s*[-*]\s*\*\*([^*]+)\*\*\s*:\s*(.*)$`)
+
+// TaskList is a parsed tasks.md file.
+//
+// The file format is a free-form preamble followed by task sections:
+//
+//	## Task: Implement login endpoint
+//	- **ID**: T1
+//	- **Status**: in progress
+//	- **Assigned by**: engineering-manager-1706012345678
+//	- **Assigned to**: software-engineer-1706012345999 (shared board tasks only)
+//	- **Depends on**: T1, software-engineer-1706012345999/T2
+//	- **Priority**: high (low, normal, high or urgent)
+//	- **Deadline**: 2024-01-27 17:00:00
+//	- **Created**: 2024-01-26 15:04:05
+//	- **Updated**: 2024-01-26 16:00:00
+//	Any other lines are kept as notes.
+type TaskList struct {
+	Preamble string // Free-form content before the first task
+	Tasks    []Task
+}
+
+// ParseTasks parses the content of a tasks.md file
+func ParseTasks(content string) *TaskList {
+	tl := &TaskList{}
+	var preamble []string
+	var current *Task
+	var notes []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Notes = strings.Trim(strings.Join(notes, "\n"), "\n")
+		tl.Tasks = append(tl.Tasks, *current)
+		current = nil
+		notes = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, taskHeaderPrefix) {
+			flush()
+			current = &Task{
+				Description: strings.TrimSpace(strings.TrimPrefix(trimmed, taskHeaderPrefix)),
+			}
+			continue
+		}
+
+		if current == nil {
+			preamble = append(preamble, line)
+			continue
+		}
+
+		if matches := taskFieldPattern.FindStringSubmatch(line); matches != nil {
+			if current.setField(matches[1], strings.TrimSpace(matches[2])) {
+				continue
+			}
+		}
+		notes = append(notes, line)
+	}
+	flush()
+
+	tl.Preamble = strings.TrimRight(strings.Join(preamble, "\n"), "\n")
+
+	// Tasks written before IDs existed get one assigned by position
+	for i := range tl.Tasks {
+		if tl.Tasks[i].ID == "" {
+			tl.Tasks[i].ID = tl.nextID()
+		}
+	}
+
+	return tl
+}
+
+// setField sets a known task field, reporting whether the line was consumed.
+// Values that do not parse are left as notes so rewriting the file keeps them.
+func (t *Task) setField(key, value string) bool {
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "id":
+		t.ID = value
+	case "status":
+		t.Status = NormalizeTaskStatus(value)
+	case "assigned by":
+		t.AssignedBy = value
+	case "assigned to":
+		if !strings.EqualFold(value, "none") && value != "-" {
+			t.AssignedTo = value
+		}
+	case "depends on":
+		refs := parseTaskRefs(value)
+		if len(refs) == 0 {
+			return false
+		}
+		t.DependsOn = refs
+	case "priority":
+		p, err := ParsePriority(value)
+		if err != nil {
+			return false
+		}
+		t.Priority = PriorityName(p)
+	case "deadline", "due":
+		deadline, err := ParseDeadline(value, time.Time{})
+		if err != nil {
+			return false
+		}
+		t.Deadline = deadline
+	case "created":
+		created := parseTaskTime(value)
+		if created.IsZero() {
+			return false
+		}
+		t.CreatedAt = created
+	case "updated":
+		updated := parseTaskTime(value)
+		if updated.IsZero() {
+			return false
+		}
+		t.UpdatedAt = updated
+	default:
+		return false
+	}
+	return true
+}
+
+// NormalizeTaskStatus maps the free-form status agents write to a TaskStatus
+func NormalizeTaskStatus(value string) TaskStatus {
+	status := strings.ToLower(strings.Trim(strings.TrimSpace(value), "*_`\"'"))
+
+	switch {
+	case strings.HasPrefix(status, "not") || strings.Contains(status, "incomplete") ||
+		strings.Contains(status, "pending") || strings.Contains(status, "todo"):
+		return TaskStatusNotStarted
+	case strings.Contains(status, "progress"):
+		return TaskStatusInProgress
+	case strings.Contains(status, "complete") || strings.Contains(status, "done"):
+		return TaskStatusCompleted
+	default:
+		return TaskStatus(status)
+	}
+}
+
+// parseTaskRefs splits a comma separated "Depends on" value, ignoring
+// placeholders such as "none"
+func parseTaskRefs(value string) []string {
+	var refs []string
+	for _, ref := range strings.Split(value, ",") {
+		ref = strings.Trim(strings.TrimSpace(ref), "`")
+		if ref == "" || strings.EqualFold(ref, "none") || ref == "-" {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// parseTaskTime parses a tasks.md timestamp, returning zero time if invalid
+func parseTaskTime(value string) time.Time {
+	if t, err := time.ParseInLocation(TaskTimeFormat, value, time.Local); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// String renders the task list back to tasks.md format
+func (tl *TaskList) String() string {
+	var b strings.Builder
+
+	if tl.Preamble != "" {
+		b.WriteString(tl.Preamble)
+		b.WriteString("\n")
+	}
+
+	for _, t := range tl.Tasks {
+		b.WriteString(fmt.Sprintf("\n%s %s\n", taskHeaderPrefix, t.Description))
+		b.WriteString(fmt.Sprintf("- **ID**: %s\n", t.ID))
+		b.WriteString(fmt.Sprintf("- **Status**: %s\n", t.Status))
+		if t.AssignedBy != "" {
+			b.WriteString(fmt.Sprintf("- **Assigned by**: %s\n", t.AssignedBy))
+		}
+		if t.AssignedTo != "" {
+			b.WriteString(fmt.Sprintf("- **Assigned to**: %s\n", t.AssignedTo))
+		} else if IsBoardTask(t.ID) {
+			b.WriteString("- **Assigned to**: none\n")
+		}
+		if len(t.DependsOn) > 0 {
+			b.WriteString(fmt.Sprintf("- **Depends on**: %s\n", strings.Join(t.DependsOn, ", ")))
+		}
+		if t.Priority != "" {
+			b.WriteString(fmt.Sprintf("- **Priority**: %s\n", t.Priority))
+		}
+		if !t.Deadline.IsZero() {
+			b.WriteString(fmt.Sprintf("- **Deadline**: %s\n", t.Deadline.Format(TaskTimeFormat)))
+		}
+		if !t.CreatedAt.IsZero() {
+			b.WriteString(fmt.Sprintf("- **Created**: %s\n", t.CreatedAt.Format(TaskTimeFormat)))
+		}
+		if !t.UpdatedAt.IsZero() {
+			b.WriteString(fmt.Sprintf("- **Updated**: %s\n", t.UpdatedAt.Format(TaskTimeFormat)))
+		}
+		if t.Notes != "" {
+			b.WriteString(t.Notes)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// nextID returns the next free "T<n>" task ID
+func (tl *TaskList) nextID() string {
+	return tl.nextIDWithPrefix("T")
+}
+
+// nextIDWithPrefix returns the next free "<prefix><n>" task ID
+func (tl *TaskList) nextIDWithPrefix(prefix string) string {
+	max := 0
+	for _, t := range tl.Tasks {
+		if !strings.HasPrefix(t.ID, prefix) {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(t.ID, prefix)); err == nil && n > max {
+			max = n
+		}
+	}
+	return fmt.Sprintf("%s%d", prefix, max+1)
+}
+
+// noTasksPlaceholder is the preamble line of a freshly created tasks.md
+const noTasksPlaceholder = "No tasks assigned yet."
+
+// Add appends a new not-started task and returns it
+func (tl *TaskList) Add(description, assignedBy string) *Task {
+	return tl.AddWithPrefix("T", description, assignedBy)
+}
+
+// AddWithPrefix appends a new not-started task whose ID uses the given prefix
+func (tl *TaskList) AddWithPrefix(prefix, description, assignedBy string) *Task {
+	if len(tl.Tasks) == 0 {
+		tl.Preamble = strings.TrimRight(strings.Replace(tl.Preamble, noTasksPlaceholder, "", 1), "\n")
+	}
+
+	tl.Tasks = append(tl.Tasks, Task{
+		ID:          tl.nextIDWithPrefix(prefix),
+		Description: description,
+		Status:      TaskStatusNotStarted,
+		AssignedBy:  assignedBy,
+		CreatedAt:   time.Now(),
+	})
+	return &tl.Tasks[len(tl.Tasks)-1]
+}
+
+// Find returns the task with the given ID, or nil
+func (tl *TaskList) Find(id string) *Task {
+	for i := range tl.Tasks {
+		if tl.Tasks[i].ID == id {
+			return &tl.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// SetStatus updates a task's status and its updated timestamp
+func (tl *TaskList) SetStatus(id string, status TaskStatus) error {
+	t := tl.Find(id)
+	if t == nil {
+		return fmt.Errorf("task %s not found", id)
+	}
+	t.Status = status
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Count returns the number of tasks with the given status
+func (tl *TaskList) Count(status TaskStatus) int {
+	count := 0
+	for _, t := range tl.Tasks {
+		if t.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// AllCompleted reports whether there is at least one task and all are completed
+func (tl *TaskList) AllCompleted() bool {
+	return len(tl.Tasks) > 0 && tl.Count(TaskStatusCompleted) == len(tl.Tasks)
+}
+
+// Claimable reports whether a board task is open for anyone to claim
+func (t *Task) Claimable() bool {
+	return t.AssignedTo == "" && t.Status != TaskStatusCompleted
+}
+
+// FirstWithStatus returns the first task with the given status, or nil
+func (tl *TaskList) FirstWithStatus(status TaskStatus) *Task {
+	for i := range tl.Tasks {
+		if tl.Tasks[i].Status == status {
+			return &tl.Tasks[i]
+		}
+	}
+	return nil
+}
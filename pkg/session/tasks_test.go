@@ -0,0 +1,168 @@
+package session
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleTasks = `# Tasks
+
+Notes for the whole list.
+
+## Task: Implement login endpoint
+- **ID**: T1
+- **Status**: in progress
+- **Assigned by**: engineering-manager-1706012345678
+- **Depends on**: T3, solutions-architect-1706012345000/T2
+- **Priority**: urgent
+- **Deadline**: 2024-01-27 17:00:00
+- **Created**: 2024-01-26 15:04:05
+Use the session middleware.
+- [ ] write handler
+
+## Task: Write tests
+- **Status**: **Done**
+
+## Task: Review design
+- **ID**: T3
+- **Status**: pending
+- **Priority**: whenever
+`
+
+func TestParseTasks(t *testing.T) {
+	tl := ParseTasks(sampleTasks)
+
+	if tl.Preamble != "# Tasks\n\nNotes for the whole list." {
+		t.Errorf("Preamble = %q", tl.Preamble)
+	}
+	if len(tl.Tasks) != 3 {
+		t.Fatalf("got %d tasks, want 3", len(tl.Tasks))
+	}
+
+	first := tl.Tasks[0]
+	if first.ID != "T1" || first.Description != "Implement login endpoint" || first.Status != TaskStatusInProgress {
+		t.Errorf("first task = %+v", first)
+	}
+	if first.AssignedBy != "engineering-manager-1706012345678" {
+		t.Errorf("AssignedBy = %q", first.AssignedBy)
+	}
+	if want := []string{"T3", "solutions-architect-1706012345000/T2"}; !reflect.DeepEqual(first.DependsOn, want) {
+		t.Errorf("DependsOn = %v, want %v", first.DependsOn, want)
+	}
+	if first.Priority != "urgent" || first.PriorityValue() != PriorityUrgent {
+		t.Errorf("Priority = %q", first.Priority)
+	}
+	if want := time.Date(2024, 1, 27, 17, 0, 0, 0, time.Local); !first.Deadline.Equal(want) {
+		t.Errorf("Deadline = %v, want %v", first.Deadline, want)
+	}
+	if first.Notes != "Use the session middleware.\n- [ ] write handler" {
+		t.Errorf("Notes = %q", first.Notes)
+	}
+
+	// Tasks without an ID get the next free one
+	if tl.Tasks[1].ID != "T4" || tl.Tasks[1].Status != TaskStatusCompleted {
+		t.Errorf("second task = %+v", tl.Tasks[1])
+	}
+
+	// An unparseable priority is kept as a note rather than dropped
+	if tl.Tasks[2].Priority != "" || !strings.Contains(tl.Tasks[2].Notes, "whenever") {
+		t.Errorf("third task = %+v", tl.Tasks[2])
+	}
+}
+
+func TestTaskListRoundTrip(t *testing.T) {
+	tl := ParseTasks(sampleTasks)
+	rendered := tl.String()
+
+	again := ParseTasks(rendered)
+	if !reflect.DeepEqual(tl, again) {
+		t.Errorf("round trip changed the task list:\n%s", rendered)
+	}
+	if again.String() != rendered {
+		t.Error("rendering is not stable")
+	}
+}
+
+func TestUnparsedFieldsSurviveRoundTrip(t *testing.T) {
+	input := `## Task: Ship it
+- **ID**: T1
+- **Status**: pending
+- **Depends on**: none
+- **Created**: yesterday afternoon
+- **Updated**: 2024-01-26 15:04:05
+`
+	tl := ParseTasks(input)
+	task := tl.Find("T1")
+	if task == nil {
+		t.Fatal("task T1 not parsed")
+	}
+	if len(task.DependsOn) != 0 || !task.CreatedAt.IsZero() || task.UpdatedAt.IsZero() {
+		t.Errorf("task = %+v", task)
+	}
+
+	rendered := tl.String()
+	for _, want := range []string{"- **Depends on**: none", "- **Created**: yesterday afternoon"} {
+		if strings.Count(rendered, want) != 1 {
+			t.Errorf("rendered tasks.md does not keep %q once:\n%s", want, rendered)
+		}
+	}
+	if again := ParseTasks(rendered).String(); again != rendered {
+		t.Errorf("rendering is not stable:\n%s", again)
+	}
+}
+
+func TestNormalizeTaskStatus(t *testing.T) {
+	tests := map[string]TaskStatus{
+		"not started":     TaskStatusNotStarted,
+		"Pending":         TaskStatusNotStarted,
+		"TODO":            TaskStatusNotStarted,
+		"incomplete":      TaskStatusNotStarted,
+		"In Progress":     TaskStatusInProgress,
+		"**in-progress**": TaskStatusInProgress,
+		"completed":       TaskStatusCompleted,
+		"`done`":          TaskStatusCompleted,
+		"blocked":         TaskStatus("blocked"),
+	}
+	for input, want := range tests {
+		if got := NormalizeTaskStatus(input); got != want {
+			t.Errorf("NormalizeTaskStatus(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestTaskListAdd(t *testing.T) {
+	tl := ParseTasks("# Tasks\n\n" + noTasksPlaceholder + "\n")
+
+	task := tl.Add("First", "system")
+	if task.ID != "T1" || task.Status != TaskStatusNotStarted || task.AssignedBy != "system" {
+		t.Errorf("added task = %+v", task)
+	}
+	if strings.Contains(tl.String(), noTasksPlaceholder) {
+		t.Error("placeholder kept after the first task was added")
+	}
+
+	if second := tl.Add("Second", "system"); second.ID != "T2" {
+		t.Errorf("second ID = %s, want T2", second.ID)
+	}
+	if board := tl.AddWithPrefix(BoardTaskPrefix, "Shared", "system"); board.ID != "B1" {
+		t.Errorf("board ID = %s, want B1", board.ID)
+	}
+
+	if err := tl.SetStatus("T2", TaskStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	if tl.Count(TaskStatusCompleted) != 1 || tl.AllCompleted() {
+		t.Errorf("Count/AllCompleted wrong after completing one of three tasks")
+	}
+	if err := tl.SetStatus("T9", TaskStatusCompleted); err == nil {
+		t.Error("SetStatus of an unknown task succeeded")
+	}
+}
+
+func TestAllCompletedNeedsTasks(t *testing.T) {
+	if ParseTasks("# Tasks\n").AllCompleted() {
+		t.Error("an empty task list counts as completed")
+	}
+}
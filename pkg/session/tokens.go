@@ -12,31 +12,145 @@ import (
 
 // TokenUsage tracks token consumption for a session
 type TokenUsage struct {
-	SessionID      string    `json:"session_id"`
-	Model          string    `json:"model"`           // sonnet, opus, haiku
-	InputTokens    int64     `json:"input_tokens"`
-	OutputTokens   int64     `json:"output_tokens"`
-	TotalTokens    int64     `json:"total_tokens"`
-	LastUpdated    time.Time `json:"last_updated"`
-	EstimatedCost  float64   `json:"estimated_cost"`  // in USD
+	SessionID        string    `json:"session_id"`
+	Model            string    `json:"model"`           // sonnet, opus, haiku
+	InputTokens      int64     `json:"input_tokens"`
+	OutputTokens     int64     `json:"output_tokens"`
+	CacheReadTokens  int64     `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int64     `json:"cache_write_tokens,omitempty"`
+	TotalTokens      int64     `json:"total_tokens"`
+	Invocations      int       `json:"invocations,omitempty"` // Claude runs recorded in the usage log
+	LastUpdated      time.Time `json:"last_updated"`
+	EstimatedCost    float64   `json:"estimated_cost"`  // in USD
+	Scraped          bool      `json:"scraped,omitempty"` // Parsed from worker output rather than the usage log
 }
 
 // ModelPricing defines the cost per million tokens for each model
 type ModelPricing struct {
-	InputPer1M  float64
-	OutputPer1M float64
+	InputPer1M      float64
+	OutputPer1M     float64
+	CacheReadPer1M  float64
+	CacheWritePer1M float64
 }
 
-// Pricing for Claude models (per million tokens)
-var modelPricing = map[string]ModelPricing{
-	"sonnet": {InputPer1M: 3.0, OutputPer1M: 15.0},
-	"opus":   {InputPer1M: 15.0, OutputPer1M: 75.0},
-	"haiku":  {InputPer1M: 0.25, OutputPer1M: 1.25},
+// builtinPricing is the cost of Claude models per million tokens
+var builtinPricing = map[string]ModelPricing{
+	"sonnet": {InputPer1M: 3.0, OutputPer1M: 15.0, CacheReadPer1M: 0.30, CacheWritePer1M: 3.75},
+	"opus":   {InputPer1M: 15.0, OutputPer1M: 75.0, CacheReadPer1M: 1.50, CacheWritePer1M: 18.75},
+	"haiku":  {InputPer1M: 0.25, OutputPer1M: 1.25, CacheReadPer1M: 0.03, CacheWritePer1M: 0.30},
 }
 
-// GetTokenUsage reads token usage from a session's tokens.json file
+// PricingTable prices token usage per model. Entries added with Set and Alias
+// (from the config file) take precedence over the cost Claude reports; the
+// built-in entries only price usage Claude reported no cost for.
+type PricingTable struct {
+	models     map[string]ModelPricing
+	aliases    map[string]string // Alternative model names -> keys in models
+	configured map[string]bool   // Model and alias names set from the config file
+}
+
+// DefaultPricing returns a pricing table holding the built-in prices
+func DefaultPricing() *PricingTable {
+	t := &PricingTable{
+		models:     make(map[string]ModelPricing, len(builtinPricing)),
+		aliases:    make(map[string]string),
+		configured: make(map[string]bool),
+	}
+	for model, pricing := range builtinPricing {
+		t.models[model] = pricing
+	}
+	return t
+}
+
+// Set adds or replaces the pricing for a model family or model ID
+func (t *PricingTable) Set(model string, pricing ModelPricing) {
+	t.models[model] = pricing
+	t.configured[model] = true
+}
+
+// Alias prices model as target, which must be a key in the pricing table
+func (t *PricingTable) Alias(model, target string) {
+	t.aliases[model] = target
+	t.configured[model] = true
+}
+
+// Models returns a copy of the pricing table
+func (t *PricingTable) Models() map[string]ModelPricing {
+	table := make(map[string]ModelPricing, len(t.models))
+	for model, pricing := range t.models {
+		table[model] = pricing
+	}
+	return table
+}
+
+// For returns the pricing for a model alias (sonnet) or full model ID
+// (claude-sonnet-4-5-20250929)
+func (t *PricingTable) For(model string) ModelPricing {
+	pricing, _ := t.lookup(model)
+	return pricing
+}
+
+// lookup finds the pricing for a model and reports whether it was configured.
+// Exact entries and aliases win; otherwise the longest known name contained in
+// the model ID is used, defaulting to sonnet.
+func (t *PricingTable) lookup(model string) (ModelPricing, bool) {
+	if target, ok := t.aliases[model]; ok {
+		return t.models[target], true
+	}
+	if pricing, ok := t.models[model]; ok {
+		return pricing, t.configured[model]
+	}
+
+	best := ""
+	for name := range t.models {
+		if strings.Contains(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	for alias, target := range t.aliases {
+		if _, ok := t.models[target]; ok && strings.Contains(model, alias) && len(alias) > len(best) {
+			best = alias
+		}
+	}
+	configured := t.configured[best]
+	if target, ok := t.aliases[best]; ok {
+		best = target
+	}
+	if pricing, ok := t.models[best]; ok {
+		return pricing, configured
+	}
+	return t.models["sonnet"], false
+}
+
+// Cost prices a usage record: configured pricing for the model wins, then the
+// cost Claude reported, then the built-in pricing
+func (t *PricingTable) Cost(model string, record UsageRecord) float64 {
+	pricing, configured := t.lookup(model)
+	if !configured && record.CostUSD > 0 {
+		return record.CostUSD
+	}
+	return pricing.Cost(record)
+}
+
+// Cost prices a single usage record
+func (p ModelPricing) Cost(record UsageRecord) float64 {
+	return (float64(record.InputTokens)*p.InputPer1M +
+		float64(record.OutputTokens)*p.OutputPer1M +
+		float64(record.CacheReadTokens)*p.CacheReadPer1M +
+		float64(record.CacheWriteTokens)*p.CacheWritePer1M) / 1_000_000.0
+}
+
+// GetTokenUsage returns a session's token usage, totalled from its usage log
+// when there is one and read from tokens.json otherwise. Completed and archived
+// sessions are read from the directory they were moved to.
 func (sm *SessionManager) GetTokenUsage(sessionID string) (*TokenUsage, error) {
-	tokensPath := filepath.Join(sm.getPersonaDir(sessionID), "tokens.json")
+	if usage, ok, err := sm.aggregateUsage(sessionID); err != nil {
+		return nil, err
+	} else if ok {
+		return usage, nil
+	}
+
+	tokensPath := filepath.Join(sm.sessionDataDir(sessionID), "tokens.json")
 
 	data, err := os.ReadFile(tokensPath)
 	if err != nil {
@@ -44,7 +158,7 @@ func (sm *SessionManager) GetTokenUsage(sessionID string) (*TokenUsage, error) {
 			// Create new token usage if doesn't exist
 			usage := &TokenUsage{
 				SessionID:     sessionID,
-				Model:         "sonnet", // default
+				Model:         sm.sessionModel(sessionID),
 				InputTokens:   0,
 				OutputTokens:  0,
 				TotalTokens:   0,
@@ -64,41 +178,60 @@ func (sm *SessionManager) GetTokenUsage(sessionID string) (*TokenUsage, error) {
 	return &usage, nil
 }
 
+// sessionModel returns the model a session was spawned with, defaulting to sonnet
+func (sm *SessionManager) sessionModel(sessionID string) string {
+	sess, err := readSession(filepath.Join(sm.sessionDataDir(sessionID), "session.json"))
+	if err == nil && sess.Model != "" {
+		return sess.Model
+	}
+	return "sonnet"
+}
+
 // SaveTokenUsage saves token usage to disk and updates session.json
 func (sm *SessionManager) SaveTokenUsage(usage *TokenUsage) error {
+	return sm.withLock(func() error {
+		return sm.saveTokenUsage(usage)
+	})
+}
+
+// saveTokenUsage writes tokens.json and session.json; callers must hold the workspace lock
+func (sm *SessionManager) saveTokenUsage(usage *TokenUsage) error {
 	data, err := json.MarshalIndent(usage, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	tokensPath := filepath.Join(sm.getPersonaDir(usage.SessionID), "tokens.json")
-	if err := os.WriteFile(tokensPath, data, 0644); err != nil {
+	if err := WriteFileAtomic(tokensPath, data, 0644); err != nil {
 		return err
 	}
 
 	// Also update session.json with token info
-	sessionPath := filepath.Join(sm.getPersonaDir(usage.SessionID), "session.json")
-	sessionData, err := os.ReadFile(sessionPath)
+	session, err := sm.GetSession(usage.SessionID)
 	if err != nil {
-		return nil // Session not found, but tokens.json was saved
-	}
-
-	var session Session
-	if err := json.Unmarshal(sessionData, &session); err != nil {
-		return nil // Invalid session file, but tokens.json was saved
+		return nil // Session not found or invalid, but tokens.json was saved
 	}
 
 	session.InputTokens = usage.InputTokens
 	session.OutputTokens = usage.OutputTokens
+	session.CacheReadTokens = usage.CacheReadTokens
+	session.CacheWriteTokens = usage.CacheWriteTokens
 	session.TotalTokens = usage.TotalTokens
 	session.EstimatedCost = usage.EstimatedCost
 	session.Model = usage.Model
 
-	return sm.saveSession(&session)
+	return sm.saveSession(session)
 }
 
-// UpdateTokenUsage updates token counts and recalculates cost
+// UpdateTokenUsage updates token counts scraped from worker output and recalculates cost
 func (sm *SessionManager) UpdateTokenUsage(sessionID string, inputTokens, outputTokens int64) error {
+	return sm.withLock(func() error {
+		return sm.updateTokenUsage(sessionID, inputTokens, outputTokens)
+	})
+}
+
+// updateTokenUsage implements UpdateTokenUsage; callers must hold the workspace lock
+func (sm *SessionManager) updateTokenUsage(sessionID string, inputTokens, outputTokens int64) error {
 	usage, err := sm.GetTokenUsage(sessionID)
 	if err != nil {
 		return err
@@ -108,21 +241,19 @@ func (sm *SessionManager) UpdateTokenUsage(sessionID string, inputTokens, output
 	usage.OutputTokens = outputTokens
 	usage.TotalTokens = inputTokens + outputTokens
 	usage.LastUpdated = time.Now()
+	usage.Scraped = true
 
 	// Calculate estimated cost
-	pricing, ok := modelPricing[usage.Model]
-	if !ok {
-		pricing = modelPricing["sonnet"] // default
-	}
-
-	inputCost := (float64(inputTokens) / 1_000_000.0) * pricing.InputPer1M
-	outputCost := (float64(outputTokens) / 1_000_000.0) * pricing.OutputPer1M
-	usage.EstimatedCost = inputCost + outputCost
+	usage.EstimatedCost = sm.pricing.For(usage.Model).Cost(UsageRecord{
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+	})
 
-	return sm.SaveTokenUsage(usage)
+	return sm.saveTokenUsage(usage)
 }
 
-// ParseTokensFromTmux extracts token usage from tmux pane output
+// ParseTokensFromTmux extracts token usage from tmux pane output. It is only a
+// fallback for workers that do not write a usage log.
 func ParseTokensFromTmux(tmuxOutput string) (inputTokens, outputTokens int64, found bool) {
 	// Look for patterns like:
 	// "Token usage: 12345/200000"
@@ -153,9 +284,10 @@ func ParseTokensFromTmux(tmuxOutput string) (inputTokens, outputTokens int64, fo
 	return 0, 0, false
 }
 
-// GetTotalTeamCost calculates the total cost across all active sessions
+// GetTotalTeamCost calculates the total cost across all sessions, including
+// completed and archived ones, and returns each session's usage by ID
 func (sm *SessionManager) GetTotalTeamCost() (float64, map[string]*TokenUsage, error) {
-	sessions, err := sm.GetAllSessions()
+	sessions, err := sm.GetSessionHistory()
 	if err != nil {
 		return 0, nil, err
 	}
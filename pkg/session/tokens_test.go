@@ -0,0 +1,74 @@
+package session
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPricingTableCostPrecedence(t *testing.T) {
+	record := UsageRecord{InputTokens: 1000000, OutputTokens: 1000000, CostUSD: 0.5}
+
+	table := DefaultPricing()
+	if got := table.Cost("claude-opus-4-1", record); got != 0.5 {
+		t.Errorf("built-in pricing cost = %v, want the reported 0.5", got)
+	}
+	if got := table.Cost("claude-opus-4-1", UsageRecord{InputTokens: 1000000}); got != 15.0 {
+		t.Errorf("cost without a reported cost = %v, want 15", got)
+	}
+
+	// Configured pricing wins over the reported cost, including through aliases
+	table.Set("opus", ModelPricing{InputPer1M: 1, OutputPer1M: 2})
+	table.Alias("my-proxy", "opus")
+	for _, model := range []string{"opus", "claude-opus-4-1", "my-proxy", "my-proxy-large"} {
+		if got := table.Cost(model, record); math.Abs(got-3) > 1e-9 {
+			t.Errorf("Cost(%q) = %v, want 3 from the configured pricing", model, got)
+		}
+	}
+	if got := table.Cost("claude-sonnet-4-5", record); got != 0.5 {
+		t.Errorf("unconfigured model cost = %v, want the reported 0.5", got)
+	}
+}
+
+func TestPricingTablesAreIndependent(t *testing.T) {
+	configured := DefaultPricing()
+	configured.Set("sonnet", ModelPricing{InputPer1M: 100})
+
+	if got := DefaultPricing().For("sonnet").InputPer1M; got != 3.0 {
+		t.Errorf("default sonnet input price = %v after configuring another table", got)
+	}
+
+	sm := newTestManager(t)
+	if sm.Pricing().For("sonnet").InputPer1M != 3.0 {
+		t.Error("new session manager does not use the built-in pricing")
+	}
+	sm.SetPricing(configured)
+	if sm.Pricing().For("sonnet").InputPer1M != 100 {
+		t.Error("SetPricing did not replace the pricing table")
+	}
+}
+
+func TestGetTokenUsageOfCompletedSession(t *testing.T) {
+	sm := newTestManager(t)
+	sess := createTestSession(t, sm, SessionTypeSoftwareEngineer)
+
+	usage := `{"model":"claude-sonnet-4-5","input_tokens":100,"output_tokens":50,"cost_usd":0.25}` + "\n"
+	if err := os.WriteFile(sm.UsageLogPath(sess.ID), []byte(usage), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The orchestrator moves finished sessions aside
+	dir := filepath.Join(sm.GetWorkspacePath(), sess.ID)
+	if err := os.Rename(dir, dir+"-completed"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := sm.GetTokenUsage(sess.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalTokens != 150 || got.EstimatedCost != 0.25 || got.Model != "claude-sonnet-4-5" {
+		t.Errorf("usage of a completed session = %+v", got)
+	}
+}
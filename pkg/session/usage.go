@@ -0,0 +1,112 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// UsageLogFile is the per-session log the worker script appends to after every
+// Claude invocation, one JSON record per line
+const UsageLogFile = "usage.jsonl"
+
+// UsageRecord is the token usage reported by a single Claude invocation
+type UsageRecord struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Model            string    `json:"model"`
+	InputTokens      int64     `json:"input_tokens"`
+	OutputTokens     int64     `json:"output_tokens"`
+	CacheReadTokens  int64     `json:"cache_read_tokens"`
+	CacheWriteTokens int64     `json:"cache_write_tokens"`
+	CostUSD          float64   `json:"cost_usd,omitempty"` // Cost reported by Claude, if any
+	DurationMS       int64     `json:"duration_ms"`
+}
+
+// UsageLogPath returns the path of a session's usage log, following the
+// session into its completed or archived directory
+func (sm *SessionManager) UsageLogPath(sessionID string) string {
+	return filepath.Join(sm.sessionDataDir(sessionID), UsageLogFile)
+}
+
+// ReadUsageLog returns every usage record logged for a session. Lines that
+// cannot be parsed, such as one still being written, are skipped.
+func (sm *SessionManager) ReadUsageLog(sessionID string) ([]UsageRecord, error) {
+	file, err := os.Open(sm.UsageLogPath(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []UsageRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record UsageRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// aggregateUsage totals a session's usage log. It returns false if the session
+// has no usage log, i.e. its worker predates JSON output.
+func (sm *SessionManager) aggregateUsage(sessionID string) (*TokenUsage, bool, error) {
+	records, err := sm.ReadUsageLog(sessionID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	usage := &TokenUsage{
+		SessionID:   sessionID,
+		Model:       sm.sessionModel(sessionID),
+		LastUpdated: time.Now(),
+	}
+	if info, err := os.Stat(sm.UsageLogPath(sessionID)); err == nil {
+		usage.LastUpdated = info.ModTime()
+	}
+
+	for _, record := range records {
+		usage.InputTokens += record.InputTokens
+		usage.OutputTokens += record.OutputTokens
+		usage.CacheReadTokens += record.CacheReadTokens
+		usage.CacheWriteTokens += record.CacheWriteTokens
+		usage.Invocations++
+
+		if record.Model != "" {
+			usage.Model = record.Model
+		}
+
+		usage.EstimatedCost += sm.pricing.Cost(usage.Model, record)
+	}
+	usage.TotalTokens = usage.InputTokens + usage.OutputTokens + usage.CacheReadTokens + usage.CacheWriteTokens
+
+	return usage, true, nil
+}
+
+// SyncTokenUsage recomputes a session's tokens.json and session.json token
+// fields from its usage log. It returns false if the session has no usage log.
+func (sm *SessionManager) SyncTokenUsage(sessionID string) (bool, error) {
+	found := false
+	err := sm.withLock(func() error {
+		usage, ok, err := sm.aggregateUsage(sessionID)
+		if err != nil || !ok {
+			return err
+		}
+		found = true
+		return sm.saveTokenUsage(usage)
+	})
+	return found, err
+}